@@ -27,6 +27,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 )
 
 const (
@@ -36,7 +37,9 @@ const (
 
 	// Maximum allowed depth when recursively substituing variable names.
 	_DEPTH_VALUES = 99
-	_VERSION      = "1.21.1"
+	// Maximum allowed depth of nested "exec" statements by default.
+	_DEPTH_EXEC = 32
+	_VERSION    = "1.21.1"
 )
 
 // Version returns current package version literal.
@@ -59,8 +62,82 @@ var (
 
 	// Explicitly write DEFAULT section header
 	DefaultHeader = false
+
+	// Indicate whether to write statements that shared a line, e.g.
+	// "mp_freezetime 15; mp_roundtime 1.92", on lines of their own.
+	ExpandStatements = false
+
+	// Indicate which values to wrap in "" when writing.
+	ValueQuoting = QuoteKeep
+
+	// Indicate whether to write lines that are not changed since they were
+	// read byte for byte, with their own whitespace, quoting, line breaks
+	// and BOM. Changed statements keep the spacing of their line and the
+	// other options only apply to them and to new lines.
+	PreserveFormat = false
 )
 
+// QuotePolicy represents which values are wrapped in "" when writing.
+type QuotePolicy int
+
+const (
+	// QuoteKeep quotes values that were quoted when read,
+	// and any value that needs it.
+	QuoteKeep QuotePolicy = iota
+	// QuoteAlways quotes every value.
+	QuoteAlways
+	// QuoteMinimal quotes only values that would not be read back
+	// as a single argument of the same kind otherwise. A quoted word
+	// loses its quotes, while a quoted number such as sv_password "1234"
+	// keeps them.
+	QuoteMinimal
+)
+
+// canRepresent returns true if val can be written so that it is read back
+// as is. The console has no escape sequences, so a quote always ends a
+// quoted argument and a line break always ends a statement.
+func canRepresent(val string) bool {
+	return strings.IndexAny(val, "\"\r\n") < 0
+}
+
+// checkArgs returns ErrUnrepresentableValue for the first of args
+// that cannot be written.
+func checkArgs(name string, args []Arg) error {
+	for _, arg := range args {
+		if !canRepresent(arg.Value) {
+			return ErrUnrepresentableValue{name, arg.Value}
+		}
+	}
+	return nil
+}
+
+// needQuote returns true if arg must be wrapped in "" to be read back as is.
+func needQuote(val string) bool {
+	return len(val) == 0 || strings.IndexFunc(val, unicode.IsSpace) >= 0 ||
+		strings.IndexByte(val, ';') >= 0 || strings.Contains(val, "//")
+}
+
+// quoteArg returns arg as written with ValueQuoting.
+func quoteArg(arg Arg) string {
+	switch {
+	case ValueQuoting == QuoteAlways,
+		ValueQuoting == QuoteKeep && arg.Quoted,
+		arg.Quoted && inferKind(arg.Value) != KindIdent,
+		needQuote(arg.Value):
+		return `"` + arg.Value + `"`
+	}
+	return arg.Value
+}
+
+// formatComment returns comment with "//" prefix. Text of the comment is
+// kept as is, so that commented out statements (e.g. "//sv_cheats 1") stay.
+func formatComment(comment string) string {
+	if !strings.HasPrefix(comment, "//") {
+		return "// " + comment
+	}
+	return comment
+}
+
 func init() {
 	if runtime.GOOS == "windows" {
 		LineBreak = "\r\n"
@@ -111,6 +188,15 @@ func (s *sourceData) ReadCloser() (io.ReadCloser, error) {
 	return &bytesReadCloser{bytes.NewReader(s.data)}, nil
 }
 
+// sourceName returns file name of given data source,
+// or an empty string for content in memory.
+func sourceName(s dataSource) string {
+	if sf, ok := s.(sourceFile); ok {
+		return sf.name
+	}
+	return ""
+}
+
 // File represents a combination of a or more CFG file(s) in memory.
 type File struct {
 	// Should make things safe, but sometimes doesn't matter.
@@ -128,6 +214,18 @@ type File struct {
 
 	options LoadOptions
 
+	// Files loaded by "exec" statements, the chain of files that led
+	// to this one, and how many "exec" statements deep it is.
+	includes  []*Include
+	execStack []string
+	execDepth int
+
+	// Errors found by the last reload with LoadOptions.CollectErrors.
+	parseErrors ParseErrors
+
+	// Whether the first data source starts with BOM.
+	bom bool
+
 	NameMapper
 	ValueMapper
 }
@@ -164,6 +262,16 @@ type LoadOptions struct {
 	// AllowBooleanKeys indicates whether to allow boolean type keys or treat as value is missing.
 	// This type of keys are mostly used in my.cnf.
 	AllowBooleanKeys bool
+	// ResolveExec indicates whether to load files named by "exec" statements recursively.
+	ResolveExec bool
+	// CfgPath is list of directories to search for files named by "exec" statements.
+	// Directory of the file holding the statement is used when empty.
+	CfgPath []string
+	// MaxExecDepth is maximum allowed depth of nested "exec" statements, 32 when zero.
+	MaxExecDepth int
+	// CollectErrors indicates whether to keep parsing after bad lines and return all
+	// errors as ParseErrors, along with a file holding everything that could be parsed.
+	CollectErrors bool
 }
 
 func LoadSources(opts LoadOptions, source interface{}, others ...interface{}) (_ *File, err error) {
@@ -180,6 +288,9 @@ func LoadSources(opts LoadOptions, source interface{}, others ...interface{}) (_
 	}
 	f := newFile(sources, opts)
 	if err = f.Reload(); err != nil {
+		if _, ok := err.(ParseErrors); ok {
+			return f, err
+		}
 		return nil, err
 	}
 	return f, nil
@@ -311,28 +422,55 @@ func (f *File) DeleteSection(name string) {
 	}
 }
 
-func (f *File) reload(s dataSource) error {
+func (f *File) reload(index int, s dataSource) error {
 	r, err := s.ReadCloser()
 	if err != nil {
 		return err
 	}
 	defer r.Close()
 
-	return f.parse(r)
+	return f.parse(r, Location{Source: sourceName(s), Index: index})
+}
+
+// reset drops everything read from data sources, so that parsing them again
+// does not append a second copy of every line.
+func (f *File) reset() {
+	if f.BlockMode {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+	}
+
+	f.sections = make(map[string]*Section)
+	f.sectionList = f.sectionList[:0]
+	f.parseErrors = nil
 }
 
 // Reload reloads and parses all data sources.
+// With LoadOptions.CollectErrors, errors found in the content
+// are returned together as ParseErrors.
 func (f *File) Reload() (err error) {
-	for _, s := range f.dataSources {
-		if err = f.reload(s); err != nil {
+	f.reset()
+	for i, s := range f.dataSources {
+		if err = f.reload(i, s); err != nil {
 			// In loose mode, we create an empty default section for nonexistent files.
 			if os.IsNotExist(err) && f.options.Loose {
-				f.parse(bytes.NewBuffer(nil))
+				f.parse(bytes.NewBuffer(nil), Location{Source: sourceName(s), Index: i})
 				continue
 			}
 			return err
 		}
 	}
+
+	if f.options.ResolveExec {
+		if err = f.resolveExec(); err != nil {
+			return err
+		}
+		f.linkHistory()
+	}
+
+	if len(f.parseErrors) > 0 {
+		return f.parseErrors
+	}
 	return nil
 }
 
@@ -353,15 +491,28 @@ func (f *File) Append(source interface{}, others ...interface{}) error {
 	return f.Reload()
 }
 
-// WriteTo writes content into io.Writer
+// WriteTo writes content into io.Writer. It returns ErrUnrepresentableValue
+// without writing anything if a value holds a quote or a line break.
 func (f *File) WriteTo(w io.Writer) (n int64, err error) {
 	// Use buffer to make sure target is safe until finish encoding.
 	buf := bytes.NewBuffer(nil)
+
+	lineBreak := LineBreak
+	if PreserveFormat {
+		lineBreak = f.lineBreak()
+		if f.bom {
+			buf.WriteString(_BOM)
+		}
+	}
+	// Whether the last line written has no line break, which happens to
+	// the last line of a data source.
+	openLine := false
+
 	for _, sname := range f.sectionList {
 		sec := f.Section(sname)
 
 		// Write nothing if default section is empty
-		if len(sec.keyList) == 0 {
+		if len(sec.nodes) == 0 {
 			continue
 		}
 
@@ -379,38 +530,110 @@ func (f *File) WriteTo(w io.Writer) (n int64, err error) {
 		}
 		alignSpaces := bytes.Repeat([]byte(" "), alignLength)
 
-		for _, kname := range sec.keyList {
-			key := sec.Key(kname)
+		for i := 0; i < len(sec.nodes); i++ {
+			nd := sec.nodes[i]
+			if openLine && !nd.inline {
+				buf.WriteString(lineBreak)
+				openLine = false
+			}
 
-			if _, err = buf.WriteString(kname); err != nil {
-				return 0, err
+			if PreserveFormat {
+				if count := unchangedLine(sec.nodes[i:]); count > 0 {
+					buf.WriteString(nd.line.text + nd.line.newline)
+					openLine = len(nd.line.newline) == 0
+					i += count - 1
+					continue
+				}
 			}
 
-			// Write out alignment spaces before value
-			if PrettyFormat {
-				buf.Write(alignSpaces[:alignLength-len(kname)+1])
+			// Line break of the line the node was read from, if it ends there.
+			eol := LineBreak
+			if PreserveFormat {
+				eol = lineBreak
+				if nd.line != nil && (i+1 == len(sec.nodes) || sec.nodes[i+1].line != nd.line) {
+					eol = nd.line.newline
+				}
+			}
+
+			switch nd.typ {
+			case _TOKEN_BLANK:
+				buf.WriteString(eol)
+				openLine = len(eol) == 0
+				continue
+			case _TOKEN_COMMENT:
+				if _, err = buf.WriteString(formatComment(nd.comment) + eol); err != nil {
+					return 0, err
+				}
+				openLine = len(eol) == 0
+				continue
+			case _TOKEN_CONFLICT:
+				if _, err = buf.WriteString(nd.conflict.markers()); err != nil {
+					return 0, err
+				}
+				continue
+			case _TOKEN_RAW:
+				if PreserveFormat {
+					buf.WriteString(nd.line.text + eol)
+					openLine = len(eol) == 0
+				}
+				continue
 			}
 
-			val := key.value
-			// Wrap strings in ""
-			if key.isString {
-				val = `"` + val + `"`
+			key := nd.key
+			kname := key.name
+			if err = key.CheckValue(); err != nil {
+				return 0, err
 			}
 
-			if _, err = buf.WriteString(val); err != nil {
+			// Keep statements that shared a line together, unless the
+			// comment of this one would swallow the next.
+			joinNext := !ExpandStatements && len(key.Comment) == 0 &&
+				i+1 < len(sec.nodes) && sec.nodes[i+1].inline
+			sharedLine := joinNext || (!ExpandStatements && nd.inline)
+
+			if _, err = buf.WriteString(kname); err != nil {
 				return 0, err
 			}
 
+			// Commands without arguments are written on their own.
+			if key.HasValue() {
+				// Write out alignment spaces before value
+				switch {
+				case PreserveFormat && nd.raw != nil && len(nd.raw.space) > 0:
+					buf.WriteString(nd.raw.space)
+				case PrettyFormat && !PreserveFormat && !sharedLine:
+					buf.Write(alignSpaces[:alignLength-len(kname)+1])
+				default:
+					buf.WriteString(" ")
+				}
+
+				for j, arg := range key.args {
+					if j > 0 {
+						buf.WriteString(" ")
+					}
+
+					if _, err = buf.WriteString(quoteArg(arg)); err != nil {
+						return 0, err
+					}
+				}
+			}
+
 			if len(key.Comment) > 0 {
-				if len(key.Comment) > 1 && key.Comment[0] != '/' && key.Comment[1] != '/' {
-					key.Comment = " // " + key.Comment
+				space := " "
+				if PreserveFormat && nd.raw != nil && len(nd.raw.commentSpace) > 0 {
+					space = nd.raw.commentSpace
 				}
-				if _, err = buf.WriteString(key.Comment); err != nil {
+				if _, err = buf.WriteString(space + formatComment(key.Comment)); err != nil {
 					return 0, err
 				}
 			}
 
-			buf.WriteString(LineBreak)
+			if joinNext {
+				buf.WriteString("; ")
+			} else {
+				buf.WriteString(eol)
+				openLine = len(eol) == 0
+			}
 		}
 	}
 
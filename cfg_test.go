@@ -16,6 +16,7 @@ package csgo_cfg
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"time"
 
@@ -97,9 +98,6 @@ func Test_Load(t *testing.T) {
 
 			_, err = Load([]byte(`=`))
 			So(err, ShouldNotBeNil)
-
-			_, err = Load([]byte(`name`))
-			So(err, ShouldNotBeNil)
 		})
 
 		Convey("Load with bad values", func() {
@@ -108,6 +106,65 @@ func Test_Load(t *testing.T) {
 		})
 	})
 
+	Convey("Load commands without arguments", t, func() {
+		cfg, err := Load([]byte(`bot_kick
+mp_warmup_end // end warmup
+writeid
+bot_quota ""`))
+		So(err, ShouldBeNil)
+		So(cfg, ShouldNotBeNil)
+
+		sec := cfg.Section("")
+		So(sec.Key("bot_kick").HasValue(), ShouldBeFalse)
+		So(sec.Key("mp_warmup_end").HasValue(), ShouldBeFalse)
+		So(sec.Key("mp_warmup_end").Comment, ShouldEqual, "// end warmup")
+		So(sec.Key("writeid").Value(), ShouldBeEmpty)
+		So(sec.Key("bot_quota").HasValue(), ShouldBeTrue)
+		So(sec.Key("bot_quota").Value(), ShouldBeEmpty)
+
+		Convey("Setting a value turns the command into a cvar", func() {
+			k := sec.Key("writeid")
+			k.SetValue("1")
+			So(k.HasValue(), ShouldBeTrue)
+		})
+	})
+
+	Convey("Get location of parse errors", t, func() {
+		_, err := Load([]byte("mp_maxrounds 30\n  hostname \"Test"))
+		So(IsErrDelimiterNotFound(err), ShouldBeTrue)
+
+		perr, ok := err.(*ParseError)
+		So(ok, ShouldBeTrue)
+		So(perr.Kind, ShouldEqual, ErrKindDelimiterNotFound)
+		So(perr.Line, ShouldEqual, 2)
+		So(perr.Column, ShouldEqual, 12)
+		So(perr.Error(), ShouldEqual, `<source 0>:2:12: key-value delimiter not found: "Test`)
+
+		_, err = Load([]byte("mp_maxrounds 30"), "testdata/conf.cfg", []byte("sv_cheats 0; =1"))
+		So(IsErrInvalidKeyName(err), ShouldBeTrue)
+		So(err.(*ParseError).Location, ShouldResemble, Location{Index: 2, Line: 1, Column: 14})
+	})
+
+	Convey("Load with collecting all errors", t, func() {
+		cfg, err := LoadSources(LoadOptions{CollectErrors: true}, []byte(`mp_maxrounds 30
+[section]
+hostname "Test
+sv_cheats 0; =bad; bot_kick`), []byte(`mp_warmup_end; say "hi`))
+		So(cfg, ShouldNotBeNil)
+
+		errs, ok := err.(ParseErrors)
+		So(ok, ShouldBeTrue)
+		So(errs, ShouldHaveLength, 4)
+		So(errs[0].Kind, ShouldEqual, ErrKindInvalidKeyName)
+		So(errs[0].Line, ShouldEqual, 2)
+		So(errs[1].Kind, ShouldEqual, ErrKindDelimiterNotFound)
+		So(errs[1].Line, ShouldEqual, 3)
+		So(errs[2].Column, ShouldEqual, 14)
+		So(errs[3].Index, ShouldEqual, 1)
+
+		So(cfg.Section("").KeyStrings(), ShouldResemble, []string{"mp_maxrounds", "sv_cheats", "mp_warmup_end"})
+	})
+
 	Convey("Get section and key insensitively", t, func() {
 		cfg, err := InsensitiveLoad([]byte(_CONF_DATA), "testdata/conf.cfg")
 		So(err, ShouldBeNil)
@@ -159,6 +216,24 @@ func Test_File_Append(t *testing.T) {
 			So(cfg.Append([]byte(""), 1), ShouldNotBeNil)
 		})
 	})
+
+	Convey("Reload and append without doubling lines", t, func() {
+		cfg, err := Load([]byte("bind a +jump\nbind b +duck\nsv_cheats 0"))
+		So(err, ShouldBeNil)
+		So(cfg.Reload(), ShouldBeNil)
+		So(cfg.Section("").KeysByName("bind"), ShouldHaveLength, 2)
+
+		So(cfg.Append([]byte("sv_cheats 1")), ShouldBeNil)
+		sec := cfg.Section("")
+		So(sec.KeysByName("bind"), ShouldHaveLength, 2)
+		So(sec.KeysByName("sv_cheats"), ShouldHaveLength, 2)
+		So(sec.Key("sv_cheats").Value(), ShouldEqual, "1")
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(strings.Count(buf.String(), "bind"), ShouldEqual, 2)
+	})
 }
 
 func Test_File_WriteTo(t *testing.T) {
@@ -169,6 +244,213 @@ func Test_File_WriteTo(t *testing.T) {
 	})
 }
 
+func Test_File_WriteTo_Commands(t *testing.T) {
+	Convey("Write commands without arguments", t, func() {
+		cfg, err := Load([]byte("mp_warmup_end // end warmup\nmp_maxrounds 30\nbot_kick\nhostname \"\"\n"))
+		So(err, ShouldBeNil)
+
+		_, err = cfg.Section("").NewCommand("writeid")
+		So(err, ShouldBeNil)
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual,
+			"mp_warmup_end // end warmup"+LineBreak+
+				"mp_maxrounds  30"+LineBreak+
+				"bot_kick"+LineBreak+
+				"hostname      \"\""+LineBreak+
+				"writeid"+LineBreak)
+	})
+}
+
+func Test_File_WriteTo_Comments(t *testing.T) {
+	Convey("Write standalone comments and blank lines back in place", t, func() {
+		data := "// Warmup settings" + LineBreak +
+			"mp_warmup_time 60" + LineBreak +
+			LineBreak +
+			"// Match settings" + LineBreak +
+			"//mp_maxrounds 16" + LineBreak +
+			"mp_maxrounds   30 // MR15" + LineBreak +
+			LineBreak +
+			LineBreak +
+			"mp_warmup_end" + LineBreak
+		cfg, err := Load([]byte(data))
+		So(err, ShouldBeNil)
+		So(cfg.Section("").KeyStrings(), ShouldResemble, []string{"mp_warmup_time", "mp_maxrounds", "mp_warmup_end"})
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+
+		Convey("Edit a single key without touching the rest", func() {
+			cfg.Section("").Key("mp_maxrounds").SetValue("24")
+			cfg.Section("").NewBlankLine()
+			cfg.Section("").NewComment("Overtime")
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, strings.Replace(data, "30", "24", 1)+
+				LineBreak+
+				"// Overtime"+LineBreak)
+		})
+
+		Convey("Delete a key but keep surrounding comments", func() {
+			cfg.Section("").DeleteKey("mp_warmup_time")
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldStartWith, "// Warmup settings"+LineBreak+LineBreak+"// Match settings")
+		})
+	})
+}
+
+func Test_File_WriteTo_Args(t *testing.T) {
+	Convey("Write keys with multiple arguments", t, func() {
+		data := `bind "MOUSE1" "+attack"` + LineBreak +
+			`bind "MOUSE2" "+attack2"` + LineBreak +
+			`alias jt "+jump;-attack"` + LineBreak +
+			`say hello world` + LineBreak
+		cfg, err := Load([]byte(data))
+		So(err, ShouldBeNil)
+
+		PrettyFormat = false
+		defer func() { PrettyFormat = true }()
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+
+		Convey("Quote values that would not be read back as one argument", func() {
+			cfg.Section("").Key("say").SetValue("gl hf")
+			cfg.Section("").NewKey("hostname", "")
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEndWith, `say "gl hf"`+LineBreak+`hostname ""`+LineBreak)
+		})
+
+		Convey("Quote values by policy", func() {
+			defer func() { ValueQuoting = QuoteKeep }()
+
+			ValueQuoting = QuoteMinimal
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldStartWith, `bind MOUSE1 +attack`+LineBreak)
+			So(buf.String(), ShouldContainSubstring, `alias jt "+jump;-attack"`+LineBreak)
+
+			ValueQuoting = QuoteAlways
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEndWith, `say "hello" "world"`+LineBreak)
+		})
+	})
+}
+
+func Test_File_WriteTo_Quotes(t *testing.T) {
+	Convey("Write values with special characters", t, func() {
+		data := `hostname "Pro // League; EU" // main server` + LineBreak +
+			`sv_downloadurl "http://fastdl.example.com/csgo"` + LineBreak +
+			`sv_logsdir "logs\"; log on` + LineBreak
+		cfg, err := Load([]byte(data))
+		So(err, ShouldBeNil)
+		sec := cfg.Section("")
+		So(sec.Key("hostname").Value(), ShouldEqual, "Pro // League; EU")
+		So(sec.Key("hostname").Comment, ShouldEqual, "// main server")
+		So(sec.Key("sv_downloadurl").Value(), ShouldEqual, "http://fastdl.example.com/csgo")
+		So(sec.Key("sv_logsdir").Value(), ShouldEqual, `logs\`)
+		So(sec.Key("log").Value(), ShouldEqual, "on")
+
+		PrettyFormat = false
+		defer func() { PrettyFormat = true }()
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+
+		Convey("Refuse values that cannot be represented", func() {
+			_, err := sec.NewKey("sv_tags", `say "hi"`)
+			So(IsErrUnrepresentableValue(err), ShouldBeTrue)
+			_, err = sec.NewCommand("say", "gl", `hf\nquit`)
+			So(err, ShouldBeNil)
+			_, err = sec.NewCommand("say", "line"+LineBreak+"quit")
+			So(IsErrUnrepresentableValue(err), ShouldBeTrue)
+
+			sec.Key("hostname").SetValue(`The "Best" Server`)
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(IsErrUnrepresentableValue(err), ShouldBeTrue)
+			So(buf.Len(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Keep single quotes as part of values", t, func() {
+		cfg, err := Load([]byte("sv_tags 'casual'\nsay 'gl hf'"))
+		So(err, ShouldBeNil)
+		sec := cfg.Section("")
+		So(sec.Key("sv_tags").Value(), ShouldEqual, "'casual'")
+		So(sec.Key("say").Arg(0), ShouldEqual, "'gl")
+		So(sec.Key("say").Arg(1), ShouldEqual, "hf'")
+	})
+}
+
+func Test_File_WriteTo_Statements(t *testing.T) {
+	Convey("Write statements sharing a line", t, func() {
+		data := "mp_freezetime 15; mp_roundtime 1.92; mp_restartgame 1 // go live" + LineBreak +
+			"mp_maxrounds   30" + LineBreak
+		cfg, err := Load([]byte(data))
+		So(err, ShouldBeNil)
+
+		sec := cfg.Section("")
+		So(sec.KeyStrings(), ShouldResemble, []string{"mp_freezetime", "mp_roundtime", "mp_restartgame", "mp_maxrounds"})
+		So(sec.Key("mp_roundtime").Value(), ShouldEqual, "1.92")
+		So(sec.Key("mp_restartgame").Comment, ShouldEqual, "// go live")
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+
+		Convey("Expand statements to one per line", func() {
+			ExpandStatements = true
+			defer func() { ExpandStatements = false }()
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual,
+				"mp_freezetime  15"+LineBreak+
+					"mp_roundtime   1.92"+LineBreak+
+					"mp_restartgame 1 // go live"+LineBreak+
+					"mp_maxrounds   30"+LineBreak)
+		})
+
+		Convey("Delete the first statement of a line", func() {
+			sec.DeleteKey("mp_freezetime")
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldStartWith, "mp_roundtime 1.92; mp_restartgame 1 // go live"+LineBreak)
+		})
+	})
+
+	Convey("Semicolons inside quotes do not end a statement", t, func() {
+		cfg, err := Load([]byte(`alias jt "+jump;-attack";bot_kick;;`))
+		So(err, ShouldBeNil)
+		So(cfg.Section("").Key("alias").Arg(1), ShouldEqual, "+jump;-attack")
+		So(cfg.Section("").HasKey("bot_kick"), ShouldBeTrue)
+	})
+}
+
 func Test_File_SaveTo_WriteTo(t *testing.T) {
 	Convey("Save file", t, func() {
 		cfg, err := Load([]byte(_CONF_DATA), "testdata/conf.cfg")
@@ -0,0 +1,124 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfgdiff shows which convars differ between two CS:GO configs.
+//
+// Usage:
+//
+//	cfgdiff [flags] old.cfg new.cfg
+//
+// Effective values are compared key by key, so changes of order, formatting,
+// quoting and comments are not reported unless asked. Names are compared
+// regardless of case, and "bind" and "alias" are compared for each key or
+// alias they set, e.g. "~ bind MOUSE1 +attack -> +jump". Each line of output is
+// "+ name value" for added keys, "- name value" for removed keys and
+// "~ name old -> new" for changed ones. It exits with status 1 if the
+// configs differ, like diff.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+var (
+	numeric  = flag.Bool("numeric", false, "treat values that are the same number as equal, e.g. 1.0 and 1")
+	quotes   = flag.Bool("quotes", false, "report values that are only quoted differently")
+	comments = flag.Bool("comments", false, "report changed comments")
+	exec     = flag.Bool("exec", false, "follow exec statements")
+	cfgPath  = flag.String("cfgpath", "", "list of directories to search for exec'd files, separated by '"+string(filepath.ListSeparator)+"'")
+	asJSON   = flag.Bool("json", false, "write changes as JSON")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfgdiff [flags] old.cfg new.cfg\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cfgdiff:", err)
+	os.Exit(2)
+}
+
+func load(name string) (*cfg.File, error) {
+	opts := cfg.LoadOptions{ResolveExec: *exec}
+	if len(*cfgPath) > 0 {
+		opts.CfgPath = filepath.SplitList(*cfgPath)
+	}
+	return cfg.LoadSources(opts, name)
+}
+
+type jsonChange struct {
+	Type     cfg.ChangeType `json:"type"`
+	Name     string         `json:"name"`
+	OldValue string         `json:"old,omitempty"`
+	NewValue string         `json:"new,omitempty"`
+	OldAt    string         `json:"old_at,omitempty"`
+	NewAt    string         `json:"new_at,omitempty"`
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	a, err := load(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	b, err := load(flag.Arg(1))
+	if err != nil {
+		fatal(err)
+	}
+
+	changes := cfg.DiffWithOptions(cfg.DiffOptions{
+		NumericEquivalence: *numeric,
+		Quoting:            *quotes,
+		Comments:           *comments,
+	}, a, b)
+
+	if *asJSON {
+		out := make([]jsonChange, len(changes))
+		for i, c := range changes {
+			out[i] = jsonChange{Type: c.Type, Name: c.Name, OldValue: c.OldValue, NewValue: c.NewValue}
+			if c.Old != nil {
+				out[i].OldAt = c.Old.Location().String()
+			}
+			if c.New != nil {
+				out[i].NewAt = c.New.Location().String()
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(out); err != nil {
+			fatal(err)
+		}
+	} else {
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfgdrift checks that a live CS:GO server runs the values of a config.
+//
+// Usage:
+//
+//	cfgdrift -addr host:port [flags] file [file ...]
+//
+// It connects over RCON, queries every cvar the config sets and lists the
+// ones whose value on the server is different. Only names known to be cvars,
+// by the bundled schema or the one given by -schema, are queried; others are
+// listed as not queried, without sending them. The RCON password is read
+// from the RCON_PASSWORD environment variable unless -password is given.
+// It exits with status 1 if there is any drift.
+//
+// The bundled schema only knows common cvars, so with it, names that are not
+// queried also make it exit with status 1, after a warning with their count.
+// Give -schema with a cvarlist dump of the server to query every cvar, or
+// -allow-unqueried to accept names that are not queried.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+	"github.com/metalmichael/go-csgo-cfg/rcon"
+)
+
+var (
+	addr     = flag.String("addr", "127.0.0.1:27015", "RCON address of the server")
+	password = flag.String("password", "", "RCON password, $RCON_PASSWORD by default")
+	timeout  = flag.Duration("timeout", 30*time.Second, "time limit of the whole check")
+	exec     = flag.Bool("exec", true, "follow exec statements")
+	cfgPath  = flag.String("cfgpath", "", "list of directories to search for exec'd files, separated by '"+string(filepath.ListSeparator)+"'")
+	asJSON   = flag.Bool("json", false, "write mismatches as JSON")
+	schema   = flag.String("schema", "", "cvarlist or find dump of cvars to query instead of the bundled schema")
+
+	allowUnqueried = flag.Bool("allow-unqueried", false, "exit with status 0 when names of the config are not queried")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfgdrift -addr host:port [flags] file [file ...]\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cfgdrift:", err)
+	os.Exit(2)
+}
+
+func loadSchema() (*cfg.Schema, error) {
+	if len(*schema) == 0 {
+		return cfg.DefaultSchema(), nil
+	}
+
+	f, err := os.Open(*schema)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return cfg.ParseCvarlist(f)
+}
+
+// exitStatus returns exit status for mismatches, and the number of names
+// that were not queried. Those fail the check unless accepted.
+func exitStatus(mismatches []*rcon.Mismatch, acceptUnqueried bool) (int, int) {
+	status, unqueried := 0, 0
+	for _, m := range mismatches {
+		if m.Kind == rcon.DriftNotQueried {
+			unqueried++
+			if !acceptUnqueried {
+				status = 1
+			}
+		} else {
+			status = 1
+		}
+	}
+	return status, unqueried
+}
+
+type jsonMismatch struct {
+	Kind     rcon.DriftKind `json:"kind"`
+	Name     string         `json:"name"`
+	Expected string         `json:"expected"`
+	Actual   string         `json:"actual,omitempty"`
+	At       string         `json:"at"`
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	opts := cfg.LoadOptions{ResolveExec: *exec}
+	if len(*cfgPath) > 0 {
+		opts.CfgPath = filepath.SplitList(*cfgPath)
+	}
+	sources := make([]interface{}, flag.NArg())
+	for i, name := range flag.Args() {
+		sources[i] = name
+	}
+	f, err := cfg.LoadSources(opts, sources[0], sources[1:]...)
+	if err != nil {
+		fatal(err)
+	}
+
+	s, err := loadSchema()
+	if err != nil {
+		fatal(err)
+	}
+
+	pass := *password
+	if len(pass) == 0 {
+		pass = os.Getenv("RCON_PASSWORD")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	conn, err := rcon.Dial(ctx, *addr, pass)
+	if err != nil {
+		fatal(err)
+	}
+	defer conn.Close()
+
+	mismatches, err := rcon.DriftWithSchema(ctx, conn, f, s)
+	if err != nil {
+		fatal(err)
+	}
+
+	if *asJSON {
+		out := make([]jsonMismatch, len(mismatches))
+		for i, m := range mismatches {
+			out[i] = jsonMismatch{m.Kind, m.Key.Name(), m.Expected, m.Actual, m.Key.Location().String()}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(out); err != nil {
+			fatal(err)
+		}
+	} else {
+		for _, m := range mismatches {
+			fmt.Println(m)
+		}
+	}
+
+	// A dump of the server knows every cvar, so names missing from it are
+	// commands or typos.
+	status, unqueried := exitStatus(mismatches, *allowUnqueried || len(*schema) > 0)
+	if unqueried > 0 && len(*schema) == 0 {
+		fmt.Fprintf(os.Stderr, "cfgdrift: warning: %d names are not in the bundled schema and were not queried; "+
+			"use -schema with a cvarlist dump of the server to check them, or -allow-unqueried\n", unqueried)
+	}
+	if status != 0 {
+		conn.Close()
+		os.Exit(status)
+	}
+}
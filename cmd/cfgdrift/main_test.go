@@ -0,0 +1,43 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/metalmichael/go-csgo-cfg/rcon"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_ExitStatus(t *testing.T) {
+	Convey("Get exit status of mismatches", t, func() {
+		notQueried := &rcon.Mismatch{Kind: rcon.DriftNotQueried}
+		changed := &rcon.Mismatch{Kind: rcon.DriftChanged}
+
+		status, unqueried := exitStatus(nil, false)
+		So(status, ShouldEqual, 0)
+		So(unqueried, ShouldEqual, 0)
+
+		status, unqueried = exitStatus([]*rcon.Mismatch{notQueried, notQueried}, false)
+		So(status, ShouldEqual, 1)
+		So(unqueried, ShouldEqual, 2)
+
+		status, _ = exitStatus([]*rcon.Mismatch{notQueried}, true)
+		So(status, ShouldEqual, 0)
+
+		status, _ = exitStatus([]*rcon.Mismatch{notQueried, changed}, true)
+		So(status, ShouldEqual, 1)
+	})
+}
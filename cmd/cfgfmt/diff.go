@@ -0,0 +1,172 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Number of unchanged lines to show around changes.
+const _DIFF_CONTEXT = 3
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+	// Line numbers in old and new file of this line, or where it would be.
+	oldNum, newNum int
+}
+
+// splitLines splits data into lines, keeping line endings.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns edit script that turns a into b, using the longest
+// common subsequence of lines.
+func diffLines(a, b []string) []diffOp {
+	// Skip common prefix and suffix to keep the table small.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	x, y := a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	// lcs[i*(m+1)+j] is length of the longest common subsequence of x[i:] and y[j:].
+	n, m := len(x), len(y)
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case x[i] == y[j]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j]
+			default:
+				lcs[i*(m+1)+j] = lcs[i*(m+1)+j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	oldNum, newNum := 1, 1
+	add := func(kind byte, line string) {
+		ops = append(ops, diffOp{kind, line, oldNum, newNum})
+		if kind != '+' {
+			oldNum++
+		}
+		if kind != '-' {
+			newNum++
+		}
+	}
+
+	for _, line := range a[:pre] {
+		add(' ', line)
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && x[i] == y[j]:
+			add(' ', x[i])
+			i++
+			j++
+		case j == m || (i < n && lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]):
+			add('-', x[i])
+			i++
+		default:
+			add('+', y[j])
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suf:] {
+		add(' ', line)
+	}
+	return ops
+}
+
+// diff returns unified diff of a and b, or nil if they are the same.
+func diff(oldName, newName string, a, b []byte) []byte {
+	if bytes.Equal(a, b) {
+		return nil
+	}
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// Find next change and extend hunk while changes are close enough.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for k := first; k < len(ops) && k <= last+2*_DIFF_CONTEXT; k++ {
+			if ops[k].kind != ' ' {
+				last = k
+			}
+		}
+
+		from := first - _DIFF_CONTEXT
+		if from < start {
+			from = start
+		}
+		to := last + _DIFF_CONTEXT + 1
+		if to > len(ops) {
+			to = len(ops)
+		}
+
+		var oldCount, newCount int
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := ops[from].oldNum, ops[from].newNum
+		// Empty ranges start at the line before.
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+
+		fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[from:to] {
+			buf.WriteByte(op.kind)
+			buf.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = to
+	}
+	return buf.Bytes()
+}
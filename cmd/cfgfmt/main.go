@@ -0,0 +1,197 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfgfmt formats CS:GO .cfg files.
+//
+// Usage:
+//
+//	cfgfmt [flags] [path ...]
+//
+// Without a path, it formats standard input to standard output. Given a
+// directory, it formats every .cfg file in it, recursively. Values are
+// aligned, quoted by policy and separated from comments by one space;
+// comments and blank lines are kept as they are.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+var (
+	list    = flag.Bool("l", false, "list files whose formatting differs from cfgfmt's")
+	doDiff  = flag.Bool("d", false, "display diffs instead of rewriting files")
+	write   = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	compact = flag.Bool("compact", false, "do not align values")
+	expand  = flag.Bool("expand", false, "write statements sharing a line on lines of their own")
+	quote   = flag.String("quote", "keep", "which values to quote: keep, always or minimal")
+	crlf    = flag.Bool("crlf", false, "use CRLF line endings")
+)
+
+var exitCode = 0
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 2
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfgfmt [flags] [path ...]\n")
+	flag.PrintDefaults()
+}
+
+// setOptions applies command-line flags to the package formatting options.
+func setOptions() error {
+	cfg.PrettyFormat = !*compact
+	cfg.ExpandStatements = *expand
+
+	switch *quote {
+	case "keep":
+		cfg.ValueQuoting = cfg.QuoteKeep
+	case "always":
+		cfg.ValueQuoting = cfg.QuoteAlways
+	case "minimal":
+		cfg.ValueQuoting = cfg.QuoteMinimal
+	default:
+		return fmt.Errorf("invalid -quote value '%s'", *quote)
+	}
+
+	// Output is the same on every platform unless asked otherwise.
+	cfg.LineBreak = "\n"
+	if *crlf {
+		cfg.LineBreak = "\r\n"
+	}
+	return nil
+}
+
+// format returns src in canonical format.
+func format(src []byte) ([]byte, error) {
+	f, err := cfg.Load(src)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if _, err = f.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// processFile formats a single file, or standard input if in is given.
+func processFile(filename string, in io.Reader, out io.Writer, stdin bool) error {
+	var perm fs.FileMode = 0644
+	if in == nil {
+		fi, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+		perm = fi.Mode().Perm()
+
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	res, err := format(src)
+	if err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+
+	if !bytes.Equal(src, res) {
+		if *list {
+			fmt.Fprintln(out, filename)
+		}
+		if *write && !stdin {
+			if err = os.WriteFile(filename, res, perm); err != nil {
+				return err
+			}
+		}
+		if *doDiff {
+			out.Write(diff(filename+".orig", filename, src, res))
+		}
+	}
+
+	if !*list && !*write && !*doDiff {
+		_, err = out.Write(res)
+	}
+	return err
+}
+
+func isCfgFile(d fs.DirEntry) bool {
+	name := d.Name()
+	return !d.IsDir() && !strings.HasPrefix(name, ".") && strings.EqualFold(filepath.Ext(name), ".cfg")
+}
+
+func walkDir(path string) {
+	filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			report(err)
+		} else if isCfgFile(d) {
+			if err = processFile(path, nil, os.Stdout, false); err != nil {
+				report(err)
+			}
+		}
+		return nil
+	})
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if err := setOptions(); err != nil {
+		report(err)
+		os.Exit(exitCode)
+	}
+
+	if flag.NArg() == 0 {
+		if *write {
+			report(fmt.Errorf("error: cannot use -w with standard input"))
+		} else if err := processFile("<standard input>", os.Stdin, os.Stdout, true); err != nil {
+			report(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	for _, path := range flag.Args() {
+		switch fi, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case fi.IsDir():
+			walkDir(path)
+		default:
+			if err = processFile(path, nil, os.Stdout, false); err != nil {
+				report(err)
+			}
+		}
+	}
+	os.Exit(exitCode)
+}
@@ -0,0 +1,80 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const _SRC = `// Match settings
+mp_maxrounds 30   //MR15
+mp_roundtime "1.92"
+
+//sv_cheats 1
+bot_kick;mp_warmup_end
+`
+
+func Test_Format(t *testing.T) {
+	Convey("Format config", t, func() {
+		So(setOptions(), ShouldBeNil)
+
+		res, err := format([]byte(_SRC))
+		So(err, ShouldBeNil)
+		So(string(res), ShouldEqual, `// Match settings
+mp_maxrounds  30 //MR15
+mp_roundtime  "1.92"
+
+//sv_cheats 1
+bot_kick; mp_warmup_end
+`)
+
+		Convey("Formatted config is left alone", func() {
+			again, err := format(res)
+			So(err, ShouldBeNil)
+			So(string(again), ShouldEqual, string(res))
+		})
+	})
+
+	Convey("Report bad config", t, func() {
+		_, err := format([]byte(`hostname "unterminated`))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	Convey("Diff changed lines", t, func() {
+		a := []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n")
+		b := []byte("a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk")
+		So(string(diff("x.orig", "x", a, b)), ShouldEqual, `--- x.orig
++++ x
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,3 +8,4 @@
+ h
+ i
+ j
++k
+\ No newline at end of file
+`)
+		So(diff("x.orig", "x", a, a), ShouldBeNil)
+	})
+}
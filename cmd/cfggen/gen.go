@@ -0,0 +1,185 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// Width of generated doc comments, not counting indent.
+const _COMMENT_WIDTH = 76
+
+// fieldName returns exported Go name of convar, e.g. "MpMaxrounds"
+// for "mp_maxrounds".
+func fieldName(name string) string {
+	var buf strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		buf.WriteRune(r)
+	}
+
+	s := buf.String()
+	if len(s) == 0 || !unicode.IsUpper([]rune(s)[0]) {
+		s = "Cvar" + s
+	}
+	return s
+}
+
+// goType returns Go type of values of convar. Booleans are ints holding
+// 0 or 1, as the console stores them, since ReflectFrom writes Go bools as
+// "true" and "false", which the console reads as 0.
+func goType(cv *cfg.Convar) string {
+	switch cv.Type {
+	case cfg.ConvarBool, cfg.ConvarInt:
+		return "int"
+	case cfg.ConvarFloat:
+		return "float64"
+	}
+	return "string"
+}
+
+// defaultLiteral returns default value of convar as a Go literal,
+// or an empty string if it is the zero value of its type.
+func defaultLiteral(cv *cfg.Convar) string {
+	if cv.Type == cfg.ConvarString {
+		if len(cv.Default) == 0 {
+			return ""
+		}
+		return strconv.Quote(cv.Default)
+	}
+
+	// Dumps may print numbers as floats, e.g. "1.000000".
+	val, err := strconv.ParseFloat(strings.TrimSpace(cv.Default), 64)
+	if err != nil || val == 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		return ""
+	}
+	switch cv.Type {
+	case cfg.ConvarBool:
+		return "1"
+	case cfg.ConvarInt:
+		if val != math.Trunc(val) {
+			return ""
+		}
+		return strconv.FormatInt(int64(val), 10)
+	}
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+// rangeString returns range of convar in "[min, max]" format,
+// or an empty string if it has no limits.
+func rangeString(cv *cfg.Convar) string {
+	if !cv.HasMin && !cv.HasMax {
+		return ""
+	}
+	min, max := "-inf", "inf"
+	if cv.HasMin {
+		min = fmt.Sprint(cv.Min)
+	}
+	if cv.HasMax {
+		max = fmt.Sprint(cv.Max)
+	}
+	return "[" + min + ", " + max + "]"
+}
+
+// writeComment writes text as "//" lines wrapped at _COMMENT_WIDTH.
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if len(line) > 0 && len(line)+1+len(word) > _COMMENT_WIDTH {
+			buf.WriteString(indent + "// " + line + "\n")
+			line = ""
+		}
+		if len(line) > 0 {
+			line += " "
+		}
+		line += word
+	}
+	buf.WriteString(indent + "// " + line + "\n")
+}
+
+// generate returns formatted Go source of package pkg with a struct named typ
+// of given convars, and a Defaults function returning it with default values.
+func generate(pkg, typ string, convars []*cfg.Convar) ([]byte, error) {
+	names := make([]string, len(convars))
+	used := make(map[string]bool)
+	for i, cv := range convars {
+		name := fieldName(cv.Name)
+		// Names like "a_b" and "a__b" turn out the same.
+		for n := 2; used[name]; n++ {
+			name = fieldName(cv.Name) + strconv.Itoa(n)
+		}
+		used[name] = true
+		names[i] = name
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cfggen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	writeComment(&buf, "", fmt.Sprintf("%s holds convars of a config. Use File.MapTo to read it from a config "+
+		"and File.ReflectFrom to write it back.", typ))
+	fmt.Fprintf(&buf, "type %s struct {\n", typ)
+	for i, cv := range convars {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		doc := names[i] + " sets " + cv.Name + "."
+		if help := strings.TrimSpace(cv.Help); len(help) > 0 {
+			doc += " " + help
+			if !strings.ContainsAny(help[len(help)-1:], ".!?") {
+				doc += "."
+			}
+		}
+		if cv.Type == cfg.ConvarBool {
+			doc += " Either 0 or 1."
+		} else if r := rangeString(cv); len(r) > 0 {
+			doc += " Range " + r + "."
+		}
+		writeComment(&buf, "\t", doc)
+		fmt.Fprintf(&buf, "\t%s %s `csgo:%q`\n", names[i], goType(cv), cv.Name)
+	}
+	buf.WriteString("}\n\n")
+
+	writeComment(&buf, "", fmt.Sprintf("Defaults returns a %s holding default values of the convars.", typ))
+	fmt.Fprintf(&buf, "func Defaults() *%s {\n\treturn &%s{\n", typ, typ)
+	for i, cv := range convars {
+		if lit := defaultLiteral(cv); len(lit) > 0 {
+			fmt.Fprintf(&buf, "\t\t%s: %s,\n", names[i], lit)
+		}
+	}
+	buf.WriteString("\t}\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %v", err)
+	}
+	return src, nil
+}
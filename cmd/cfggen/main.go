@@ -0,0 +1,122 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfggen generates a Go struct of convars from a convar schema.
+//
+// Usage:
+//
+//	cfggen [flags] [prefix ...]
+//
+// The struct has a field of matching type for every convar, tagged to be
+// used with File.MapTo and File.ReflectFrom and documented by the help text
+// of the convar, and a Defaults function returning it with default values.
+// Boolean convars are int fields holding 0 or 1, so that ReflectFrom writes
+// values the console reads. MapTo does not set int fields from keys set to 0,
+// so a struct from Defaults keeps its default where a config sets 0; map to
+// a zero struct to read such keys.
+//
+// Given prefixes such as "mp_" and "sv_", only convars whose names start with
+// one of them are included. Commands have no value and are left out, as are
+// cheat-protected convars unless -cheats is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+var (
+	schema   = flag.String("schema", "", "cvarlist or find dump to generate from instead of the bundled schema")
+	pkgName  = flag.String("package", "main", "package name of generated code")
+	typeName = flag.String("type", "Config", "name of generated struct")
+	output   = flag.String("o", "", "write to file instead of stdout")
+	cheats   = flag.Bool("cheats", false, "include cheat-protected convars")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfggen [flags] [prefix ...]\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cfggen:", err)
+	os.Exit(2)
+}
+
+func loadSchema() (*cfg.Schema, error) {
+	if len(*schema) == 0 {
+		return cfg.DefaultSchema(), nil
+	}
+
+	f, err := os.Open(*schema)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return cfg.ParseCvarlist(f)
+}
+
+// selectConvars returns convars of schema that have a value and whose
+// names start with one of given prefixes, or all of them without prefixes.
+// Cheat-protected convars are left out unless cheats is true.
+func selectConvars(s *cfg.Schema, prefixes []string, cheats bool) []*cfg.Convar {
+	var convars []*cfg.Convar
+	for _, cv := range s.Convars() {
+		if cv.Type == cfg.ConvarCommand || (cv.HasFlag(cfg.FCVAR_CHEAT) && !cheats) {
+			continue
+		}
+
+		match := len(prefixes) == 0
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(strings.ToLower(cv.Name), strings.ToLower(prefix)) {
+				match = true
+				break
+			}
+		}
+		if match {
+			convars = append(convars, cv)
+		}
+	}
+	return convars
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	s, err := loadSchema()
+	if err != nil {
+		fatal(err)
+	}
+
+	convars := selectConvars(s, flag.Args(), *cheats)
+	if len(convars) == 0 {
+		fatal(fmt.Errorf("no convars to generate"))
+	}
+
+	src, err := generate(*pkgName, *typeName, convars)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(*output) == 0 {
+		os.Stdout.Write(src)
+	} else if err = os.WriteFile(*output, src, 0644); err != nil {
+		fatal(err)
+	}
+}
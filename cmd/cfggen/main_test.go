@@ -0,0 +1,87 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"testing"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Generate(t *testing.T) {
+	Convey("Generate struct of convars", t, func() {
+		s := cfg.NewSchema(
+			&cfg.Convar{Name: "mp_maxrounds", Type: cfg.ConvarInt, Default: "30", HasMin: true, Min: 0, Help: "Max number of rounds to play before server changes maps"},
+			&cfg.Convar{Name: "mp_roundtime", Type: cfg.ConvarFloat, Default: "1.920000", HasMin: true, Min: 1, HasMax: true, Max: 60},
+			&cfg.Convar{Name: "mp_teamname_1", Type: cfg.ConvarString},
+			&cfg.Convar{Name: "mp_warmup_end", Type: cfg.ConvarCommand},
+			&cfg.Convar{Name: "sv_cheats", Type: cfg.ConvarBool, Default: "0", Help: "Allow cheats on server."},
+			&cfg.Convar{Name: "sv_showimpacts", Type: cfg.ConvarInt, Default: "0", Flags: cfg.FCVAR_CHEAT},
+			&cfg.Convar{Name: "sv_pausable", Type: cfg.ConvarBool, Default: "1"},
+			&cfg.Convar{Name: "sv__pausable", Type: cfg.ConvarString, Default: "Team A"},
+			&cfg.Convar{Name: "bot_quota", Type: cfg.ConvarInt, Default: "10"},
+		)
+
+		convars := selectConvars(s, []string{"MP_", "sv_"}, false)
+		So(convars, ShouldHaveLength, 6)
+		So(selectConvars(s, []string{"sv_"}, true), ShouldHaveLength, 4)
+
+		src, err := generate("main", "Config", convars)
+		So(err, ShouldBeNil)
+		So(string(src), ShouldEqual, "// Code generated by cfggen. DO NOT EDIT.\n"+`
+package main
+
+// Config holds convars of a config. Use File.MapTo to read it from a config
+// and File.ReflectFrom to write it back.
+type Config struct {
+	// MpMaxrounds sets mp_maxrounds. Max number of rounds to play before server
+	// changes maps. Range [0, inf].
+	MpMaxrounds int `+"`csgo:\"mp_maxrounds\"`"+`
+
+	// MpRoundtime sets mp_roundtime. Range [1, 60].
+	MpRoundtime float64 `+"`csgo:\"mp_roundtime\"`"+`
+
+	// MpTeamname1 sets mp_teamname_1.
+	MpTeamname1 string `+"`csgo:\"mp_teamname_1\"`"+`
+
+	// SvCheats sets sv_cheats. Allow cheats on server. Either 0 or 1.
+	SvCheats int `+"`csgo:\"sv_cheats\"`"+`
+
+	// SvPausable sets sv_pausable. Either 0 or 1.
+	SvPausable int `+"`csgo:\"sv_pausable\"`"+`
+
+	// SvPausable2 sets sv__pausable.
+	SvPausable2 string `+"`csgo:\"sv__pausable\"`"+`
+}
+
+// Defaults returns a Config holding default values of the convars.
+func Defaults() *Config {
+	return &Config{
+		MpMaxrounds: 30,
+		MpRoundtime: 1.92,
+		SvPausable:  1,
+		SvPausable2: "Team A",
+	}
+}
+`)
+	})
+
+	Convey("Name fields of convars", t, func() {
+		So(fieldName("cl_crosshair_drawoutline"), ShouldEqual, "ClCrosshairDrawoutline")
+		So(fieldName("+jlook"), ShouldEqual, "Jlook")
+		So(fieldName("3dsky"), ShouldEqual, "Cvar3dsky")
+	})
+}
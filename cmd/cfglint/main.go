@@ -0,0 +1,190 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfglint checks CS:GO .cfg files for likely mistakes.
+//
+// Usage:
+//
+//	cfglint [flags] file [file ...]
+//
+// Each file is checked on its own, following "exec" statements. With
+// -combine, files are loaded in order as a single config instead, so that
+// e.g. a key set by both is reported. Diagnostics on a line are suppressed
+// by a comment such as "// cfglint:ignore dup-key" on the line of the
+// reported key. It exits with status 1 if anything is reported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+	"github.com/metalmichael/go-csgo-cfg/lint"
+)
+
+var (
+	format  = flag.String("format", "text", "output format: text, json or sarif")
+	schema  = flag.String("schema", "", "cvarlist or find dump to check against instead of the bundled schema")
+	exec    = flag.Bool("exec", true, "follow exec statements")
+	cfgPath = flag.String("cfgpath", "", "list of directories to search for exec'd files, separated by '"+string(filepath.ListSeparator)+"'")
+	enable  = flag.String("enable", "", "comma-separated list of rules to run, all by default")
+	disable = flag.String("disable", "", "comma-separated list of rules not to run")
+	combine = flag.Bool("combine", false, "check files as a single config, loaded in order")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfglint [flags] file [file ...]\n\nRules:\n")
+	for _, r := range lint.DefaultRules() {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", r.ID, r.Description)
+	}
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cfglint:", err)
+	os.Exit(2)
+}
+
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// selectRules returns built-in rules picked by -enable and -disable.
+func selectRules() ([]*lint.Rule, error) {
+	all := lint.DefaultRules()
+	known := make(map[string]bool, len(all))
+	for _, r := range all {
+		known[r.ID] = true
+	}
+
+	enabled, disabled := splitList(*enable), splitList(*disable)
+	for _, id := range append(enabled, disabled...) {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown rule '%s'", id)
+		}
+	}
+
+	var rules []*lint.Rule
+	for _, r := range all {
+		if (len(enabled) == 0 || inSlice(r.ID, enabled)) && !inSlice(r.ID, disabled) {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func inSlice(str string, s []string) bool {
+	for _, v := range s {
+		if str == v {
+			return true
+		}
+	}
+	return false
+}
+
+func loadSchema() (*cfg.Schema, error) {
+	if len(*schema) == 0 {
+		return cfg.DefaultSchema(), nil
+	}
+
+	f, err := os.Open(*schema)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return cfg.ParseCvarlist(f)
+}
+
+// lintSources checks given sources loaded as a single config.
+func lintSources(opts cfg.LoadOptions, s *cfg.Schema, rules []*lint.Rule, sources []interface{}) ([]*lint.Diagnostic, error) {
+	var diags []*lint.Diagnostic
+	f, err := cfg.LoadSources(opts, sources[0], sources[1:]...)
+	if err != nil {
+		errs, ok := err.(cfg.ParseErrors)
+		if !ok {
+			return nil, err
+		}
+		diags = lint.ParseErrorDiagnostics(errs)
+	}
+	return append(diags, lint.Run(f, s, rules)...), nil
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	rules, err := selectRules()
+	if err != nil {
+		fatal(err)
+	}
+	s, err := loadSchema()
+	if err != nil {
+		fatal(err)
+	}
+
+	opts := cfg.LoadOptions{
+		ResolveExec:   *exec,
+		CollectErrors: true,
+	}
+	if len(*cfgPath) > 0 {
+		opts.CfgPath = filepath.SplitList(*cfgPath)
+	}
+
+	sources := make([]interface{}, flag.NArg())
+	for i, name := range flag.Args() {
+		sources[i] = name
+	}
+
+	var diags []*lint.Diagnostic
+	if *combine {
+		if diags, err = lintSources(opts, s, rules, sources); err != nil {
+			fatal(err)
+		}
+	} else {
+		for _, source := range sources {
+			fileDiags, err := lintSources(opts, s, rules, []interface{}{source})
+			if err != nil {
+				fatal(err)
+			}
+			diags = append(diags, fileDiags...)
+		}
+	}
+	lint.SortDiagnostics(diags)
+
+	switch *format {
+	case "text":
+		err = lint.WriteText(os.Stdout, diags)
+	case "json":
+		err = lint.WriteJSON(os.Stdout, diags)
+	case "sarif":
+		err = lint.WriteSARIF(os.Stdout, "cfglint", append(rules, lint.ParseErrorRule), diags)
+	default:
+		err = fmt.Errorf("invalid -format value '%s'", *format)
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(diags) > 0 {
+		os.Exit(1)
+	}
+}
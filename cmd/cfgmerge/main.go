@@ -0,0 +1,120 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Command cfgmerge merges changes of two CS:GO configs made from a common base.
+//
+// Usage:
+//
+//	cfgmerge [flags] base.cfg ours.cfg theirs.cfg
+//
+// The result is written over ours.cfg, with statements changed by both sides
+// between conflict markers, and it exits with status 1 if there is any
+// conflict. Every line of a repeated key is merged, and "bind" and "alias"
+// are merged for each key or alias they set. Lines that the merge does not change are written as they are
+// in ours.cfg. This is what git expects from a merge driver, so it can be
+// set up in .gitconfig:
+//
+//	[merge "cfg"]
+//		name = CS:GO config merge
+//		driver = cfgmerge %O %A %B
+//
+// and in .gitattributes:
+//
+//	*.cfg merge=cfg
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+var (
+	output = flag.String("o", "", "write result to file instead of ours.cfg")
+	stdout = flag.Bool("p", false, "write result to standard output instead of ours.cfg")
+	quiet  = flag.Bool("q", false, "do not list conflicts on standard error")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: cfgmerge [flags] base.cfg ours.cfg theirs.cfg\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cfgmerge:", err)
+	os.Exit(2)
+}
+
+// merge returns the result of merging configs of given files.
+func merge(base, ours, theirs string) ([]byte, []*cfg.Conflict, error) {
+	files := make([]*cfg.File, 3)
+	for i, name := range []string{base, ours, theirs} {
+		f, err := cfg.Load(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		files[i] = f
+	}
+
+	f, conflicts := cfg.Merge(files[0], files[1], files[2])
+
+	// Do not realign lines of ours, which would show up in every diff.
+	cfg.PreserveFormat = true
+	buf := bytes.NewBuffer(nil)
+	if _, err := f.WriteTo(buf); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), conflicts, nil
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 3 {
+		usage()
+		os.Exit(2)
+	}
+
+	res, conflicts, err := merge(flag.Arg(0), flag.Arg(1), flag.Arg(2))
+	if err != nil {
+		fatal(err)
+	}
+
+	switch {
+	case *stdout:
+		if _, err = os.Stdout.Write(res); err != nil {
+			fatal(err)
+		}
+	default:
+		name := *output
+		if len(name) == 0 {
+			name = flag.Arg(1)
+		}
+		if err = os.WriteFile(name, res, 0644); err != nil {
+			fatal(err)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		if !*quiet {
+			for _, c := range conflicts {
+				fmt.Fprintf(os.Stderr, "conflict: %s\n", c.Name)
+			}
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const _BASE = `// Match settings
+hostname "A"
+mp_maxrounds 30   //MR15
+mp_freezetime	15
+sv_cheats 0
+`
+
+func writeFiles(t *testing.T, contents ...string) []string {
+	dir := t.TempDir()
+	names := make([]string, len(contents))
+	for i, c := range contents {
+		names[i] = filepath.Join(dir, string(rune('a'+i))+".cfg")
+		So(os.WriteFile(names[i], []byte(c), 0644), ShouldBeNil)
+	}
+	return names
+}
+
+func Test_Merge(t *testing.T) {
+	Convey("Keep untouched lines of ours", t, func() {
+		ours := `// Match settings
+hostname "A"
+mp_maxrounds 24   //MR12
+mp_freezetime	15
+sv_cheats 0
+`
+		theirs := `// Match settings
+hostname "A"
+mp_maxrounds 30   //MR15
+mp_freezetime	10
+sv_cheats 0
+bot_quota 0
+`
+		names := writeFiles(t, _BASE, ours, theirs)
+
+		res, conflicts, err := merge(names[0], names[1], names[2])
+		So(err, ShouldBeNil)
+		So(conflicts, ShouldBeEmpty)
+		So(string(res), ShouldEqual, `// Match settings
+hostname "A"
+mp_maxrounds 24   //MR12
+mp_freezetime	10
+sv_cheats 0
+bot_quota 0
+`)
+	})
+
+	Convey("Report conflicts", t, func() {
+		names := writeFiles(t, _BASE, "hostname \"B\"\n", "hostname \"C\"\n")
+		_, conflicts, err := merge(names[0], names[1], names[2])
+		So(err, ShouldBeNil)
+		So(conflicts, ShouldHaveLength, 1)
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Header of a convar in "find" output:
+	// "mp_roundtime" = "5" ( def. "5" ) min. 1.000000 max. 60.000000
+	findPattern = regexp.MustCompile(`^"([^"]+)"(?:\s*=\s*"([^"]*)")?(?:\s*\(\s*def\.\s*"([^"]*)"\s*\))?(.*)$`)
+	minPattern  = regexp.MustCompile(`\bmin\.\s*(\S+)`)
+	maxPattern  = regexp.MustCompile(`\bmax\.\s*(\S+)`)
+)
+
+// cvarlistFlags maps flag names printed by "cvarlist" and "find" to flags.
+var cvarlistFlags = map[string]ConvarFlag{
+	"devonly":               FCVAR_DEVELOPMENTONLY,
+	"sv":                    FCVAR_GAMEDLL,
+	"game":                  FCVAR_GAMEDLL,
+	"cl":                    FCVAR_CLIENTDLL,
+	"client":                FCVAR_CLIENTDLL,
+	"hidden":                FCVAR_HIDDEN,
+	"prot":                  FCVAR_PROTECTED,
+	"sp":                    FCVAR_SPONLY,
+	"singleplayer":          FCVAR_SPONLY,
+	"a":                     FCVAR_ARCHIVE,
+	"archive":               FCVAR_ARCHIVE,
+	"nf":                    FCVAR_NOTIFY,
+	"notify":                FCVAR_NOTIFY,
+	"user":                  FCVAR_USERINFO,
+	"print":                 FCVAR_PRINTABLEONLY,
+	"log":                   FCVAR_UNLOGGED,
+	"numeric":               FCVAR_NEVER_AS_STRING,
+	"rep":                   FCVAR_REPLICATED,
+	"replicated":            FCVAR_REPLICATED,
+	"cheat":                 FCVAR_CHEAT,
+	"ss":                    FCVAR_SS,
+	"demo":                  FCVAR_DEMO,
+	"norecord":              FCVAR_DONTRECORD,
+	"ss_added":              FCVAR_SS_ADDED,
+	"rel":                   FCVAR_RELEASE,
+	"release":               FCVAR_RELEASE,
+	"matsys":                FCVAR_MATERIAL_SYSTEM_THREAD,
+	"notconnected":          FCVAR_NOT_CONNECTED,
+	"per_user":              FCVAR_ARCHIVE_GAMECONSOLE,
+	"server_can_execute":    FCVAR_SERVER_CAN_EXECUTE,
+	"server_cannot_query":   FCVAR_SERVER_CANNOT_QUERY,
+	"clientcmd_can_execute": FCVAR_CLIENTCMD_CAN_EXECUTE,
+}
+
+// parseCvarlistFlags returns flags of given names, ignoring unknown ones.
+func parseCvarlistFlags(names []string) ConvarFlag {
+	var flags ConvarFlag
+	for _, name := range names {
+		flags |= cvarlistFlags[strings.ToLower(strings.Trim(name, `" `))]
+	}
+	return flags
+}
+
+// guessConvarType returns type of convar by its value. Dumps do not tell
+// integers from floats, so every number except a 0 or 1 limited to [0, 1]
+// is considered a float.
+func guessConvarType(cv *Convar) ConvarType {
+	if _, err := strconv.ParseFloat(cv.Default, 64); err != nil {
+		return ConvarString
+	}
+	if cv.HasMin && cv.Min == 0 && cv.HasMax && cv.Max == 1 &&
+		(cv.Default == "0" || cv.Default == "1") {
+		return ConvarBool
+	}
+	return ConvarFloat
+}
+
+// parseCvarlistLine parses a line of "cvarlist" output, e.g.
+// `sv_cheats : 0 : , "nf", "rep", "rel" : Allow cheats on server`.
+func parseCvarlistLine(line string) (*Convar, bool) {
+	if strings.HasSuffix(line, " :") {
+		line += " "
+	}
+	fields := strings.SplitN(line, " : ", 4)
+	if len(fields) < 3 {
+		return nil, false
+	}
+
+	cv := &Convar{
+		Name:  strings.TrimSpace(fields[0]),
+		Flags: parseCvarlistFlags(strings.Split(fields[2], ",")),
+	}
+	if len(cv.Name) == 0 || strings.ContainsAny(cv.Name, " \t") {
+		return nil, false
+	}
+	if len(fields) == 4 {
+		cv.Help = strings.TrimSpace(fields[3])
+	}
+
+	if value := strings.TrimSpace(fields[1]); value == "cmd" {
+		cv.Type = ConvarCommand
+	} else {
+		cv.Default = value
+		cv.Type = guessConvarType(cv)
+	}
+	return cv, true
+}
+
+// parseFindHeader parses the first line of a convar in "find" output.
+func parseFindHeader(line string) (*Convar, bool) {
+	m := findPattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil, false
+	}
+
+	cv := &Convar{Name: m[1], Default: m[2]}
+	if len(m[3]) > 0 {
+		cv.Default = m[3]
+	}
+	if mm := minPattern.FindStringSubmatch(m[4]); mm != nil {
+		if v, err := strconv.ParseFloat(mm[1], 64); err == nil {
+			cv.HasMin, cv.Min = true, v
+		}
+	}
+	if mm := maxPattern.FindStringSubmatch(m[4]); mm != nil {
+		if v, err := strconv.ParseFloat(mm[1], 64); err == nil {
+			cv.HasMax, cv.Max = true, v
+		}
+	}
+
+	// Commands have no value at all.
+	if !strings.Contains(line, "=") {
+		cv.Type = ConvarCommand
+	} else {
+		cv.Type = guessConvarType(cv)
+	}
+
+	// Flags may follow the range on the same line.
+	rest := maxPattern.ReplaceAllString(minPattern.ReplaceAllString(m[4], ""), "")
+	cv.Flags = parseCvarlistFlags(strings.Fields(rest))
+	return cv, true
+}
+
+// ParseCvarlist returns a schema of convars in the output of console
+// commands "cvarlist" or "find", e.g. captured from a server with
+// "con_logfile". Lines that describe no convar, such as headers and
+// the total count, are skipped.
+//
+// Dumps only show current values, which are taken as defaults. Ranges
+// are known only from "find" output, and numbers are considered floats
+// unless they are limited to 0 and 1.
+func ParseCvarlist(r io.Reader) (*Schema, error) {
+	schema := NewSchema()
+
+	// Last convar from "find" output, which continues on following lines
+	// with flags and " - " help text.
+	var last *Convar
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+
+		if last != nil && (line[0] == ' ' || line[0] == '\t') {
+			if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+				help := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+				if len(last.Help) > 0 {
+					help = last.Help + "\n" + help
+				}
+				last.Help = help
+			} else {
+				last.Flags |= parseCvarlistFlags(strings.Fields(trimmed))
+			}
+			continue
+		}
+		last = nil
+
+		if strings.HasPrefix(trimmed, `"`) {
+			if cv, ok := parseFindHeader(trimmed); ok {
+				schema.Add(cv)
+				last = cv
+			}
+			continue
+		}
+
+		if cv, ok := parseCvarlistLine(line); ok {
+			schema.Add(cv)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error when reading cvarlist: %v", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const _FIND_DATA = `] find sv_cheats
+"sv_cheats" = "0" ( def. "0" ) min. 0.000000 max. 1.000000 notify replicated release
+ - Allow cheats on server
+"mp_roundtime" = "1.92" ( def. "5" ) min. 1.000000 max. 60.000000
+ game notify replicated release
+ - How many minutes each round takes.
+"bot_kick"
+ game release
+ - Kicks a specific bot, or all bots.
+`
+
+func Test_ParseCvarlist(t *testing.T) {
+	Convey("Parse cvarlist output", t, func() {
+		f, err := os.Open("testdata/cvarlist.txt")
+		So(err, ShouldBeNil)
+		defer f.Close()
+
+		schema, err := ParseCvarlist(f)
+		So(err, ShouldBeNil)
+		So(schema.Convars(), ShouldHaveLength, 5)
+
+		cv := schema.Convar("bot_kick")
+		So(cv.Type, ShouldEqual, ConvarCommand)
+		So(cv.Flags, ShouldEqual, FCVAR_GAMEDLL)
+
+		cv = schema.Convar("hostname")
+		So(cv.Type, ShouldEqual, ConvarString)
+		So(cv.Default, ShouldEqual, "My Server")
+
+		cv = schema.Convar("mp_roundtime")
+		So(cv.Type, ShouldEqual, ConvarFloat)
+		So(cv.Default, ShouldEqual, "5")
+		So(cv.Flags, ShouldEqual, FCVAR_GAMEDLL|FCVAR_NOTIFY|FCVAR_REPLICATED|FCVAR_RELEASE)
+		So(cv.Help, ShouldEqual, "How many minutes each round takes.")
+
+		cv = schema.Convar("tv_name")
+		So(cv.Default, ShouldBeEmpty)
+		So(cv.Help, ShouldBeEmpty)
+
+		Convey("Validate against parsed schema", func() {
+			cfg, err := Load([]byte("mp_roundtime 1.92\nsv_cheats abc\nmp_maxrounds 30"))
+			So(err, ShouldBeNil)
+			errs := cfg.Validate(schema)
+			So(errs, ShouldHaveLength, 2)
+			So(errs[0].Kind, ShouldEqual, ErrKindTypeMismatch)
+			So(errs[1].Kind, ShouldEqual, ErrKindUnknownConvar)
+		})
+	})
+
+	Convey("Parse find output", t, func() {
+		schema, err := ParseCvarlist(strings.NewReader(_FIND_DATA))
+		So(err, ShouldBeNil)
+		So(schema.Convars(), ShouldHaveLength, 3)
+
+		cv := schema.Convar("sv_cheats")
+		So(cv.Type, ShouldEqual, ConvarBool)
+		So(cv.Flags, ShouldEqual, FCVAR_NOTIFY|FCVAR_REPLICATED|FCVAR_RELEASE)
+		So(cv.Help, ShouldEqual, "Allow cheats on server")
+
+		cv = schema.Convar("mp_roundtime")
+		So(cv.Type, ShouldEqual, ConvarFloat)
+		So(cv.Default, ShouldEqual, "5")
+		So(cv.Min, ShouldEqual, 1)
+		So(cv.Max, ShouldEqual, 60)
+		So(cv.HasFlag(FCVAR_GAMEDLL|FCVAR_REPLICATED), ShouldBeTrue)
+
+		cv = schema.Convar("bot_kick")
+		So(cv.Type, ShouldEqual, ConvarCommand)
+		So(cv.HasMin, ShouldBeFalse)
+		So(cv.Flags, ShouldEqual, FCVAR_GAMEDLL|FCVAR_RELEASE)
+	})
+}
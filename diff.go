@@ -0,0 +1,281 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeType represents how a key differs between two configs.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "changed"
+)
+
+// Change represents difference of a key between two configs.
+type Change struct {
+	Type ChangeType
+	// Name is the statement as written, e.g. "sv_cheats", or "bind MOUSE1"
+	// for commands that keep a value for each first argument.
+	Name string
+	// OldValue and NewValue are effective values of the key, without the
+	// first argument that is part of Name. OldValue is empty when the key
+	// is added, and NewValue when it is removed.
+	OldValue, NewValue string
+	// Old and New are the keys that set the values, or nil
+	// where there is no value.
+	Old, New *Key
+}
+
+func (c *Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s %s", c.Name, formatStatementValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s %s", c.Name, formatStatementValue(c.Old))
+	}
+	return fmt.Sprintf("~ %s %s -> %s", c.Name, formatStatementValue(c.Old), formatStatementValue(c.New))
+}
+
+// formatArgs returns args as written in the config.
+func formatArgs(args []Arg) string {
+	vals := make([]string, len(args))
+	for i, arg := range args {
+		if arg.Quoted || needQuote(arg.Value) {
+			vals[i] = `"` + arg.Value + `"`
+		} else {
+			vals[i] = arg.Value
+		}
+	}
+	return strings.Join(vals, " ")
+}
+
+// formatKeyValue returns value and comment of key as written in the config.
+func formatKeyValue(k *Key) string {
+	val := formatArgs(k.args)
+	if len(k.Comment) > 0 {
+		val += " " + formatComment(k.Comment)
+	}
+	return val
+}
+
+// formatStatementValue returns value and comment of key as written in
+// the config, without the first argument that is part of statement name.
+func formatStatementValue(k *Key) string {
+	val := formatArgs(statementArgs(k))
+	if len(k.Comment) > 0 {
+		val += " " + formatComment(k.Comment)
+	}
+	return val
+}
+
+// _ARG_COMMANDS holds commands that keep a separate value for each first
+// argument, e.g. "bind MOUSE1 +attack" only sets what MOUSE1 does.
+var _ARG_COMMANDS = map[string]bool{
+	"alias": true,
+	"bind":  true,
+}
+
+// hasArgName returns true if first argument of key is part of its
+// statement name.
+func hasArgName(k *Key) bool {
+	return _ARG_COMMANDS[strings.ToLower(k.name)] && len(k.args) > 0
+}
+
+// statementID returns what key is compared by: its name, lower-cased as
+// the console ignores case, followed by the first argument for commands
+// in _ARG_COMMANDS, e.g. "bind mouse1".
+func statementID(k *Key) string {
+	if hasArgName(k) {
+		return strings.ToLower(k.name + " " + k.args[0].Value)
+	}
+	return strings.ToLower(k.name)
+}
+
+// statementName returns statement name of key as written, e.g. "bind MOUSE1".
+func statementName(k *Key) string {
+	if hasArgName(k) {
+		return k.name + " " + k.args[0].Value
+	}
+	return k.name
+}
+
+// statementArgs returns arguments of key that make its value.
+func statementArgs(k *Key) []Arg {
+	if hasArgName(k) {
+		return k.args[1:]
+	}
+	return k.args
+}
+
+// statementValue returns value of key without the first argument that is
+// part of its statement name.
+func statementValue(k *Key) string {
+	args := statementArgs(k)
+	vals := make([]string, len(args))
+	for i := range args {
+		vals[i] = args[i].Value
+	}
+	return strings.Join(vals, " ")
+}
+
+// DiffOptions contains options of comparing configs.
+type DiffOptions struct {
+	// Indicates whether values that are the same number are equal, e.g. "1.0" and "1".
+	NumericEquivalence bool
+	// Indicates whether to report values that are only quoted differently.
+	Quoting bool
+	// Indicates whether to report keys whose comment is changed.
+	Comments bool
+}
+
+// effectiveKeys returns names of keys in the order they are first set,
+// and the keys that set their effective values. Files loaded by "exec"
+// statements are followed when they are resolved.
+func (f *File) effectiveKeys() ([]string, map[string]string, map[string]*Key) {
+	sec := f.Section(DEFAULT_SECTION)
+	if len(f.includes) == 0 {
+		names, hash := sec.KeyStrings(), sec.KeysHash()
+		keys := make(map[string]*Key, len(names))
+		for _, name := range names {
+			keys[name] = sec.Key(name)
+		}
+		return names, hash, keys
+	}
+
+	var names []string
+	hash := make(map[string]string)
+	keys := make(map[string]*Key)
+	f.walkKeys(func(k *Key) {
+		if _, ok := keys[k.name]; !ok {
+			names = append(names, k.name)
+		}
+		hash[k.name] = k.Value()
+		keys[k.name] = k
+	})
+	return names, hash, keys
+}
+
+// statementKeys returns statement IDs of keys in the order they are first
+// set, and the keys that set their effective values. Files loaded by "exec"
+// statements are followed when they are resolved.
+func (f *File) statementKeys() ([]string, map[string]*Key) {
+	var ids []string
+	keys := make(map[string]*Key)
+	f.walkKeys(func(k *Key) {
+		id := statementID(k)
+		if _, ok := keys[id]; !ok {
+			ids = append(ids, id)
+		}
+		keys[id] = k
+	})
+	return ids, keys
+}
+
+// equalValues returns true if values are the same by given options.
+func (opts DiffOptions) equalValues(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if opts.NumericEquivalence {
+		x, errX := strconv.ParseFloat(a, 64)
+		y, errY := strconv.ParseFloat(b, 64)
+		return errX == nil && errY == nil && x == y
+	}
+	return false
+}
+
+// equalKeys returns true if keys with equal values are also the same by given options.
+func (opts DiffOptions) equalKeys(a, b *Key) bool {
+	if opts.Comments && a.Comment != b.Comment {
+		return false
+	}
+	if opts.Quoting {
+		if len(a.args) != len(b.args) {
+			return false
+		}
+		for i := range a.args {
+			if a.args[i].Quoted != b.args[i].Quoted {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Diff compares effective values of keys in two configs, ignoring order,
+// formatting, quoting, comments and case of names. Changes are sorted by
+// statement name.
+func Diff(a, b *File) []*Change {
+	return DiffWithOptions(DiffOptions{}, a, b)
+}
+
+// DiffWithOptions compares effective values of keys in two configs
+// with given options. Commands that keep a value for each first argument,
+// i.e. "bind" and "alias", are compared for each of it, so that
+// "bind MOUSE1 +attack" and "bind MOUSE2 +attack2" are separate
+// statements. Other repeated keys are compared by their last statement.
+func DiffWithOptions(opts DiffOptions, a, b *File) []*Change {
+	oldIDs, oldKeys := a.statementKeys()
+	newIDs, newKeys := b.statementKeys()
+
+	var changes []*Change
+	for _, id := range oldIDs {
+		oldKey, newKey := oldKeys[id], newKeys[id]
+		if newKey == nil {
+			changes = append(changes, &Change{
+				Type:     ChangeRemoved,
+				Name:     statementName(oldKey),
+				OldValue: statementValue(oldKey),
+				Old:      oldKey,
+			})
+			continue
+		}
+
+		oldVal, newVal := statementValue(oldKey), statementValue(newKey)
+		if !opts.equalValues(oldVal, newVal) || !opts.equalKeys(oldKey, newKey) {
+			changes = append(changes, &Change{
+				Type:     ChangeModified,
+				Name:     statementName(newKey),
+				OldValue: oldVal,
+				NewValue: newVal,
+				Old:      oldKey,
+				New:      newKey,
+			})
+		}
+	}
+
+	for _, id := range newIDs {
+		if newKey := newKeys[id]; oldKeys[id] == nil {
+			changes = append(changes, &Change{
+				Type:     ChangeAdded,
+				Name:     statementName(newKey),
+				NewValue: statementValue(newKey),
+				New:      newKey,
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return strings.ToLower(changes[i].Name) < strings.ToLower(changes[j].Name)
+	})
+	return changes
+}
@@ -0,0 +1,98 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Diff(t *testing.T) {
+	Convey("Compare two configs", t, func() {
+		a, err := Load([]byte(`hostname "Match Server"
+mp_maxrounds 30
+mp_roundtime 1.92 // 1:55
+mp_overtime_enable 1
+mp_freezetime 15
+sv_cheats 0
+`))
+		So(err, ShouldBeNil)
+		b, err := Load([]byte(`mp_freezetime 20
+mp_overtime_enable 1.0
+mp_roundtime "1.92"
+hostname "Match Server" // renamed
+mp_maxrounds 24
+mp_maxrounds 30
+mp_halftime 1
+`))
+		So(err, ShouldBeNil)
+
+		changes := Diff(a, b)
+		So(changes, ShouldHaveLength, 4)
+		So(changes[0].Type, ShouldEqual, ChangeModified)
+		So(changes[0].Name, ShouldEqual, "mp_freezetime")
+		So(changes[0].OldValue, ShouldEqual, "15")
+		So(changes[0].NewValue, ShouldEqual, "20")
+		So(changes[0].String(), ShouldEqual, "~ mp_freezetime 15 -> 20")
+		So(changes[1].Type, ShouldEqual, ChangeAdded)
+		So(changes[1].Name, ShouldEqual, "mp_halftime")
+		So(changes[1].New.Line(), ShouldEqual, 7)
+		So(changes[2].Name, ShouldEqual, "mp_overtime_enable")
+		So(changes[3].Type, ShouldEqual, ChangeRemoved)
+		So(changes[3].String(), ShouldEqual, "- sv_cheats 0")
+
+		Convey("Compare numbers, quoting and comments", func() {
+			changes := DiffWithOptions(DiffOptions{NumericEquivalence: true, Quoting: true, Comments: true}, a, b)
+			So(changes, ShouldHaveLength, 5)
+			So(changes[0].Name, ShouldEqual, "hostname")
+			So(changes[0].String(), ShouldEqual, `~ hostname "Match Server" -> "Match Server" // renamed`)
+			So(changes[3].Name, ShouldEqual, "mp_roundtime")
+			So(changes[3].String(), ShouldEqual, `~ mp_roundtime 1.92 // 1:55 -> "1.92"`)
+			So(changes[4].Name, ShouldEqual, "sv_cheats")
+		})
+	})
+
+	Convey("Compare binds and names in other case", t, func() {
+		a, err := Load([]byte("Sv_Cheats 0\nbind a +jump\nbind b +duck\nalias go \"say gl\"\nbind c +use"))
+		So(err, ShouldBeNil)
+		b, err := Load([]byte("sv_cheats 0\nbind A +attack\nbind b +duck\nalias go \"say hf\"\nbind d +use"))
+		So(err, ShouldBeNil)
+
+		changes := Diff(a, b)
+		So(changes, ShouldHaveLength, 4)
+		So(changes[0].String(), ShouldEqual, `~ alias go "say gl" -> "say hf"`)
+		So(changes[1].Name, ShouldEqual, "bind A")
+		So(changes[1].OldValue, ShouldEqual, "+jump")
+		So(changes[1].String(), ShouldEqual, "~ bind A +jump -> +attack")
+		So(changes[2].String(), ShouldEqual, "- bind c +use")
+		So(changes[3].String(), ShouldEqual, "+ bind d +use")
+	})
+
+	Convey("Compare configs with exec statements", t, func() {
+		a, err := LoadSources(LoadOptions{ResolveExec: true}, "testdata/exec/server.cfg")
+		So(err, ShouldBeNil)
+		b, err := Load("testdata/exec/server.cfg")
+		So(err, ShouldBeNil)
+
+		changes := Diff(a, b)
+		So(changes, ShouldHaveLength, 3)
+		So(changes[0].Name, ShouldEqual, "exec")
+		So(changes[0].OldValue, ShouldEqual, "esl5on5.cfg")
+		So(changes[1].Name, ShouldEqual, "execifexists")
+		So(changes[2].Name, ShouldEqual, "mp_overtime_enable")
+		So(changes[2].Type, ShouldEqual, ChangeRemoved)
+	})
+}
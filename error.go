@@ -15,7 +15,9 @@
 package csgo_cfg
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 type ErrDelimiterNotFound struct {
@@ -23,10 +25,103 @@ type ErrDelimiterNotFound struct {
 }
 
 func IsErrDelimiterNotFound(err error) bool {
-	_, ok := err.(ErrDelimiterNotFound)
-	return ok
+	return errors.As(err, new(ErrDelimiterNotFound))
 }
 
 func (err ErrDelimiterNotFound) Error() string {
 	return fmt.Sprintf("key-value delimiter not found: %s", err.Line)
 }
+
+type ErrInvalidKeyName struct {
+	Line string
+}
+
+func IsErrInvalidKeyName(err error) bool {
+	return errors.As(err, new(ErrInvalidKeyName))
+}
+
+func (err ErrInvalidKeyName) Error() string {
+	return fmt.Sprintf("invalid key name: %s", err.Line)
+}
+
+// ErrUnrepresentableValue is returned for a value that cannot be written so
+// that it is read back as is, i.e. one holding a quote or a line break.
+type ErrUnrepresentableValue struct {
+	Key   string
+	Value string
+}
+
+func IsErrUnrepresentableValue(err error) bool {
+	return errors.As(err, new(ErrUnrepresentableValue))
+}
+
+func (err ErrUnrepresentableValue) Error() string {
+	return fmt.Sprintf("value of '%s' cannot be represented in a config: %q", err.Key, err.Value)
+}
+
+type ErrExecCycle struct {
+	Chain []string
+}
+
+func IsErrExecCycle(err error) bool {
+	return errors.As(err, new(ErrExecCycle))
+}
+
+func (err ErrExecCycle) Error() string {
+	return fmt.Sprintf("exec cycle detected: %s", strings.Join(err.Chain, " -> "))
+}
+
+type ErrExecDepth struct {
+	Name  string
+	Depth int
+}
+
+func IsErrExecDepth(err error) bool {
+	return errors.As(err, new(ErrExecDepth))
+}
+
+func (err ErrExecDepth) Error() string {
+	return fmt.Sprintf("exec depth exceeds %d: %s", err.Depth, err.Name)
+}
+
+// ParseErrorKind is a machine-readable name of a parse error.
+type ParseErrorKind string
+
+const (
+	ErrKindDelimiterNotFound ParseErrorKind = "delimiter-not-found"
+	ErrKindInvalidKeyName    ParseErrorKind = "invalid-key-name"
+	ErrKindExecNotFound      ParseErrorKind = "exec-not-found"
+	ErrKindExecCycle         ParseErrorKind = "exec-cycle"
+	ErrKindExecDepth         ParseErrorKind = "exec-depth"
+)
+
+// ParseError represents an error found at a location of a data source.
+type ParseError struct {
+	Location
+	Kind ParseErrorKind
+	Err  error
+}
+
+func IsParseError(err error) bool {
+	return errors.As(err, new(*ParseError))
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", err.Location, err.Column, err.Err)
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// ParseErrors represents all errors found while loading
+// with LoadOptions.CollectErrors.
+type ParseErrors []*ParseError
+
+func (errs ParseErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i := range errs {
+		msgs[i] = errs[i].Error()
+	}
+	return strings.Join(msgs, "\n")
+}
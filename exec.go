@@ -0,0 +1,242 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Include represents a file loaded by an "exec" statement.
+type Include struct {
+	// Name is the file name as given to "exec".
+	Name string
+	// Path is where the file was found.
+	Path string
+	// Key is the "exec" statement that loaded the file.
+	Key *Key
+	// File holds content of the file, including its own includes.
+	File *File
+}
+
+// isExecKey returns true if key loads another file, e.g. "exec esl5on5".
+func isExecKey(k *Key) bool {
+	return (strings.EqualFold(k.name, "exec") || strings.EqualFold(k.name, "execifexists")) &&
+		len(k.Arg(0)) > 0
+}
+
+// absPath returns absolute form of path so that the same file
+// is always recognized, or the cleaned path if that is not possible.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// findExec searches the cfg path for given file name, with or without
+// the ".cfg" extension. Directory of the file holding the statement is
+// searched when no cfg path is set.
+func (f *File) findExec(name, from string) (string, error) {
+	dirs := f.options.CfgPath
+	if len(dirs) == 0 {
+		dirs = []string{filepath.Dir(from)}
+	}
+
+	names := []string{name}
+	if !strings.HasSuffix(strings.ToLower(name), ".cfg") {
+		names = append(names, name+".cfg")
+	}
+
+	for _, dir := range dirs {
+		for _, n := range names {
+			path := filepath.Join(dir, n)
+			if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return "", &os.PathError{Op: "exec", Path: name, Err: os.ErrNotExist}
+}
+
+// resolveExec loads files named by "exec" statements in order,
+// and the files they name in turn.
+func (f *File) resolveExec() error {
+	maxDepth := f.options.MaxExecDepth
+	if maxDepth <= 0 {
+		maxDepth = _DEPTH_EXEC
+	}
+
+	f.includes = nil
+	for _, n := range f.Section("").nodes {
+		if n.typ != _TOKEN_KEY || !isExecKey(n.key) {
+			continue
+		}
+
+		// Only the chain of files that leads to the statement makes a cycle;
+		// other data sources are run one after another by the console.
+		stack := make([]string, len(f.execStack), len(f.execStack)+1)
+		copy(stack, f.execStack)
+		if len(n.key.loc.Source) > 0 {
+			stack = append(stack, absPath(n.key.loc.Source))
+		}
+
+		name := n.key.Arg(0)
+		path, err := f.findExec(name, n.key.loc.Source)
+		if err != nil {
+			// Missing files are skipped by the console as well.
+			if os.IsNotExist(err) && (f.options.Loose || strings.EqualFold(n.key.name, "execifexists")) {
+				continue
+			}
+			if err = f.execError(n.key, ErrKindExecNotFound, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The file is not loaded again either way when collecting errors.
+		if inSlice(absPath(path), stack) {
+			if err = f.execError(n.key, ErrKindExecCycle, ErrExecCycle{append(stack, absPath(path))}); err != nil {
+				return err
+			}
+			continue
+		} else if f.execDepth >= maxDepth {
+			if err = f.execError(n.key, ErrKindExecDepth, ErrExecDepth{name, maxDepth}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		child := newFile([]dataSource{sourceFile{path}}, f.options)
+		child.execStack = stack
+		child.execDepth = f.execDepth + 1
+		if err = child.Reload(); err != nil {
+			errs, ok := err.(ParseErrors)
+			if !ok {
+				return err
+			}
+			f.parseErrors = append(f.parseErrors, errs...)
+		}
+
+		f.includes = append(f.includes, &Include{
+			Name: name,
+			Path: path,
+			Key:  n.key,
+			File: child,
+		})
+	}
+	return nil
+}
+
+// execError returns a ParseError at given "exec" statement,
+// or records it with LoadOptions.CollectErrors.
+func (f *File) execError(k *Key, kind ParseErrorKind, err error) error {
+	return f.parseError(k.loc, k.loc.Line, k.loc.Column, kind, err)
+}
+
+// Includes returns list of files loaded by "exec" statements in order.
+// Files they load in turn are available through their own Includes.
+func (f *File) Includes() []*Include {
+	includes := make([]*Include, len(f.includes))
+	copy(includes, f.includes)
+	return includes
+}
+
+// include returns the file loaded by given "exec" statement, if any.
+func (f *File) include(k *Key) *Include {
+	for _, inc := range f.includes {
+		if inc.Key == k {
+			return inc
+		}
+	}
+	return nil
+}
+
+// walkKeys calls fn for every key in the order the console would run
+// them, following "exec" statements into the files they load.
+func (f *File) walkKeys(fn func(*Key)) {
+	if f.BlockMode {
+		f.lock.RLock()
+		defer f.lock.RUnlock()
+	}
+
+	sec := f.sections[DEFAULT_SECTION]
+	if sec == nil {
+		return
+	}
+
+	for _, n := range sec.nodes {
+		if n.typ != _TOKEN_KEY {
+			continue
+		}
+		fn(n.key)
+		if inc := f.include(n.key); inc != nil {
+			inc.File.walkKeys(fn)
+		}
+	}
+}
+
+// Statements returns every key in the order the console would run them,
+// including the ones in files loaded by "exec" statements.
+func (f *File) Statements() []*Key {
+	var keys []*Key
+	f.walkKeys(func(k *Key) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// linkHistory records for every key the earlier definitions it overrides,
+// including the ones in files loaded by "exec" statements.
+func (f *File) linkHistory() {
+	last := make(map[string]*Key)
+	f.walkKeys(func(k *Key) {
+		k.history = nil
+		if prev := last[k.name]; prev != nil {
+			k.history = append(prev.History(), prev)
+		}
+		last[k.name] = k
+	})
+}
+
+// effectiveKey returns the key with given name that is set last,
+// following "exec" statements in order.
+func (f *File) effectiveKey(name string) *Key {
+	var key *Key
+	f.walkKeys(func(k *Key) {
+		if k.name == name {
+			key = k
+		}
+	})
+	return key
+}
+
+// EffectiveKey returns the key that sets given name last, as the console
+// would see it after running every "exec" statement. Its Source and Line
+// tell which file set it.
+func (f *File) EffectiveKey(name string) (*Key, error) {
+	if f.options.Insensitive {
+		name = strings.ToLower(name)
+	}
+
+	key := f.effectiveKey(name)
+	if key == nil {
+		return nil, fmt.Errorf("error when getting effective key: key '%s' not exists", name)
+	}
+	return key, nil
+}
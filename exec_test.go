@@ -0,0 +1,159 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Exec(t *testing.T) {
+	Convey("Resolve exec statements", t, func() {
+		cfg, err := LoadSources(LoadOptions{ResolveExec: true}, "testdata/exec/server.cfg")
+		So(err, ShouldBeNil)
+		So(cfg, ShouldNotBeNil)
+
+		Convey("Get include graph", func() {
+			includes := cfg.Includes()
+			So(includes, ShouldHaveLength, 1)
+			So(includes[0].Name, ShouldEqual, "gamemode_competitive_server")
+			So(includes[0].Path, ShouldEqual, filepath.Join("testdata", "exec", "gamemode_competitive_server.cfg"))
+			So(includes[0].Key.Line(), ShouldEqual, 3)
+
+			nested := includes[0].File.Includes()
+			So(nested, ShouldHaveLength, 1)
+			So(nested[0].Name, ShouldEqual, "esl5on5.cfg")
+			So(nested[0].File.Includes(), ShouldBeEmpty)
+		})
+
+		Convey("Get statements in execution order", func() {
+			var names []string
+			for _, k := range cfg.Statements() {
+				names = append(names, k.Name()+" "+k.Value())
+			}
+			So(names, ShouldResemble, []string{
+				"hostname Test Server",
+				"mp_maxrounds 30",
+				"exec gamemode_competitive_server",
+				"mp_maxrounds 24",
+				"exec esl5on5.cfg",
+				"mp_maxrounds 30",
+				"mp_overtime_enable 1",
+				"execifexists missing",
+				"sv_cheats 0",
+			})
+		})
+
+		Convey("Get effective keys", func() {
+			k, err := cfg.EffectiveKey("mp_maxrounds")
+			So(err, ShouldBeNil)
+			So(k.Value(), ShouldEqual, "30")
+			So(k.Source(), ShouldEqual, filepath.Join("testdata", "exec", "esl5on5.cfg"))
+			So(k.Line(), ShouldEqual, 1)
+
+			history := k.History()
+			So(history, ShouldHaveLength, 2)
+			So(history[0].Location().String(), ShouldEqual, "testdata/exec/server.cfg:2")
+			So(history[1].Value(), ShouldEqual, "24")
+			So(history[1].Source(), ShouldEqual, filepath.Join("testdata", "exec", "gamemode_competitive_server.cfg"))
+
+			k, err = cfg.EffectiveKey("sv_cheats")
+			So(err, ShouldBeNil)
+			So(k.Value(), ShouldEqual, "0")
+			So(k.Source(), ShouldEqual, "testdata/exec/server.cfg")
+			So(k.Line(), ShouldEqual, 4)
+
+			_, err = cfg.EffectiveKey("mp_warmuptime")
+			So(err, ShouldNotBeNil)
+
+			// Keys of the file itself are not changed by includes.
+			So(cfg.Section("").Key("mp_maxrounds").Value(), ShouldEqual, "30")
+			So(cfg.Section("").Key("mp_maxrounds").Line(), ShouldEqual, 2)
+		})
+
+		Convey("Search for files in cfg path", func() {
+			cfg, err := LoadSources(LoadOptions{
+				ResolveExec: true,
+				CfgPath:     []string{"testdata", filepath.Join("testdata", "exec")},
+			}, []byte("exec esl5on5"))
+			So(err, ShouldBeNil)
+
+			k, err := cfg.EffectiveKey("mp_overtime_enable")
+			So(err, ShouldBeNil)
+			So(k.Value(), ShouldEqual, "1")
+		})
+
+		Convey("Exec a file that is also a data source", func() {
+			cfg, err := LoadSources(LoadOptions{ResolveExec: true},
+				"testdata/exec/server.cfg", "testdata/exec/gamemode_competitive_server.cfg")
+			So(err, ShouldBeNil)
+			So(cfg.Includes(), ShouldHaveLength, 2)
+			So(cfg.Includes()[0].Name, ShouldEqual, "gamemode_competitive_server")
+			So(cfg.Includes()[1].Name, ShouldEqual, "esl5on5.cfg")
+		})
+	})
+
+	Convey("Bad exec statements", t, func() {
+		Convey("Exec missing file", func() {
+			_, err := LoadSources(LoadOptions{ResolveExec: true}, []byte("exec 404"))
+			So(err, ShouldNotBeNil)
+
+			_, err = LoadSources(LoadOptions{ResolveExec: true, Loose: true}, []byte("exec 404"))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Exec cycle", func() {
+			_, err := LoadSources(LoadOptions{ResolveExec: true}, "testdata/exec/cycle_a.cfg")
+			So(IsErrExecCycle(err), ShouldBeTrue)
+		})
+
+		Convey("Exec too deep", func() {
+			_, err := LoadSources(LoadOptions{ResolveExec: true, MaxExecDepth: 1}, "testdata/exec/server.cfg")
+			So(IsErrExecDepth(err), ShouldBeTrue)
+		})
+
+		Convey("Exec at maximum depth", func() {
+			// server.cfg execs gamemode_competitive_server.cfg, which execs esl5on5.cfg.
+			cfg, err := LoadSources(LoadOptions{ResolveExec: true, MaxExecDepth: 2}, "testdata/exec/server.cfg")
+			So(err, ShouldBeNil)
+			So(cfg.Includes()[0].File.Includes(), ShouldHaveLength, 1)
+		})
+
+		Convey("Exec errors are collected", func() {
+			cfg, err := LoadSources(LoadOptions{ResolveExec: true, CollectErrors: true}, "testdata/exec/cycle_a.cfg", []byte("exec 404"))
+			So(cfg, ShouldNotBeNil)
+
+			errs, ok := err.(ParseErrors)
+			So(ok, ShouldBeTrue)
+			So(errs, ShouldHaveLength, 2)
+			So(errs[0].Kind, ShouldEqual, ErrKindExecCycle)
+			So(errs[0].Source, ShouldEqual, filepath.Join("testdata", "exec", "cycle_b.cfg"))
+			So(errs[1].Kind, ShouldEqual, ErrKindExecNotFound)
+			So(errs[1].Index, ShouldEqual, 1)
+
+			k, err := cfg.EffectiveKey("sv_cheats")
+			So(err, ShouldBeNil)
+			So(k.Value(), ShouldEqual, "1")
+		})
+
+		Convey("Exec without resolving", func() {
+			cfg, err := Load("testdata/exec/cycle_a.cfg")
+			So(err, ShouldBeNil)
+			So(cfg.Includes(), ShouldBeEmpty)
+		})
+	})
+}
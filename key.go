@@ -21,12 +21,134 @@ import (
 	"time"
 )
 
+// Location represents where a key was read from.
+type Location struct {
+	// Source is name of the file, or empty for data in memory.
+	Source string
+	// Index is position of the data source given to Load.
+	Index int
+	// Line and Column start from 1, and are zero for keys
+	// that were not read from a data source.
+	Line   int
+	Column int
+}
+
+// String returns location in "source:line" format. Data in memory
+// is named by its position, e.g. "<source 0>:12".
+func (l Location) String() string {
+	source := l.Source
+	if len(source) == 0 {
+		source = fmt.Sprintf("<source %d>", l.Index)
+	}
+	return fmt.Sprintf("%s:%d", source, l.Line)
+}
+
+// Arg represents a single argument of a key.
+type Arg struct {
+	Value string
+	// Quoted indicates whether the argument is wrapped in "".
+	Quoted bool
+}
+
+// Kind returns kind of argument inferred from how it was written.
+// A quoted argument is always a string, whatever it holds, and so is one
+// that will be written quoted, e.g. a value with spaces set by SetValue.
+func (a Arg) Kind() ValueKind {
+	if a.Quoted || needQuote(a.Value) {
+		return KindString
+	}
+	return inferKind(a.Value)
+}
+
+// ValueKind represents kind of a value, e.g. a number or a word.
+type ValueKind int
+
+const (
+	// KindNone is kind of a command given without any argument, e.g. "bot_kick".
+	KindNone ValueKind = iota
+	// KindBool is kind of "0" and "1", which may be a boolean or a number.
+	KindBool
+	// KindInt is kind of integers, e.g. "30" or "-1".
+	KindInt
+	// KindFloat is kind of decimal numbers, e.g. "1.92" or ".5".
+	KindFloat
+	// KindIdent is kind of any other unquoted word, e.g. "de_dust2" or "0xFF8000".
+	KindIdent
+	// KindString is kind of quoted values, values written quoted such as ones
+	// with spaces, and values of several arguments.
+	KindString
+)
+
+var valueKindNames = []string{"none", "bool", "int", "float", "ident", "string"}
+
+func (k ValueKind) String() string {
+	if k < 0 || int(k) >= len(valueKindNames) {
+		return fmt.Sprintf("ValueKind(%d)", int(k))
+	}
+	return valueKindNames[k]
+}
+
+// skipDigits returns number of leading ASCII digits of s.
+func skipDigits(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// inferKind returns kind of an unquoted value. Numbers are only what the
+// console reads as such, so "inf", "1_000" or "0x10" are words.
+func inferKind(val string) ValueKind {
+	switch val {
+	case "0", "1":
+		return KindBool
+	}
+
+	s := val
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	intLen := skipDigits(s)
+	if intLen > 0 && intLen == len(s) {
+		return KindInt
+	}
+
+	s = s[intLen:]
+	fracLen := 0
+	if len(s) > 0 && s[0] == '.' {
+		fracLen = skipDigits(s[1:])
+		s = s[1+fracLen:]
+	}
+	if intLen+fracLen == 0 {
+		return KindIdent
+	}
+	if len(s) > 0 && (s[0] == 'e' || s[0] == 'E') {
+		s = s[1:]
+		if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+			s = s[1:]
+		}
+		expLen := skipDigits(s)
+		if expLen == 0 {
+			return KindIdent
+		}
+		s = s[expLen:]
+	}
+	if len(s) > 0 {
+		return KindIdent
+	}
+	return KindFloat
+}
+
 // Key represents a key under a section.
 type Key struct {
-	s        *Section
-	name     string
-	value    string
-	isString bool
+	s    *Section
+	name string
+	args []Arg
+
+	// Where the key was read from, and earlier definitions it overrides.
+	loc     Location
+	history []*Key
 
 	Comment string
 }
@@ -39,14 +161,115 @@ func (k *Key) Name() string {
 	return k.name
 }
 
+// Location returns where key was read from.
+func (k *Key) Location() Location {
+	return k.loc
+}
+
+// Source returns name of the file key was read from,
+// or an empty string if it was not read from a file.
+func (k *Key) Source() string {
+	return k.loc.Source
+}
+
+// Line returns line number key was read from,
+// or zero if it was not read from a data source.
+func (k *Key) Line() int {
+	return k.loc.Line
+}
+
+// Column returns column number key was read from,
+// or zero if it was not read from a data source.
+func (k *Key) Column() int {
+	return k.loc.Column
+}
+
+// History returns earlier definitions of the same key that are
+// overridden by this one, oldest first.
+func (k *Key) History() []*Key {
+	history := make([]*Key, len(k.history))
+	copy(history, k.history)
+	return history
+}
+
 // Value returns raw value of key for performance purpose.
+// Arguments of keys like "mp_teamname_1 Team Liquid" are joined by a space,
+// the same way the console assigns them to a cvar.
 func (k *Key) Value() string {
-	return k.value
+	switch len(k.args) {
+	case 0:
+		return ""
+	case 1:
+		return k.args[0].Value
+	}
+
+	vals := make([]string, len(k.args))
+	for i := range k.args {
+		vals[i] = k.args[i].Value
+	}
+	return strings.Join(vals, " ")
+}
+
+// HasValue returns false if key is a command given without any argument,
+// e.g. "bot_kick", as opposed to one set to an empty string.
+func (k *Key) HasValue() bool {
+	return len(k.args) > 0
+}
+
+// Kind returns kind of value of key, inferred when it was read or set.
+// Keys of several arguments, e.g. "mp_teamname_1 Team Liquid", are strings.
+func (k *Key) Kind() ValueKind {
+	switch len(k.args) {
+	case 0:
+		return KindNone
+	case 1:
+		return k.args[0].Kind()
+	}
+	return KindString
+}
+
+// Args returns list of arguments of key, e.g. "MOUSE1" and "+attack"
+// for "bind MOUSE1 +attack".
+func (k *Key) Args() []Arg {
+	args := make([]Arg, len(k.args))
+	copy(args, k.args)
+	return args
+}
+
+// Arg returns value of argument at given index,
+// or an empty string if key has fewer arguments.
+func (k *Key) Arg(i int) string {
+	if i < 0 || i >= len(k.args) {
+		return ""
+	}
+	return k.args[i].Value
+}
+
+// NumArgs returns number of arguments of key.
+func (k *Key) NumArgs() int {
+	return len(k.args)
+}
+
+// Statement returns key as a statement the console can run, without
+// comment, e.g. `hostname "My Server"`. Arguments are quoted as they
+// were read, and wherever it is needed.
+func (k *Key) Statement() string {
+	if !k.HasValue() {
+		return k.name
+	}
+	return k.name + " " + formatArgs(k.args)
+}
+
+// CheckValue returns ErrUnrepresentableValue if an argument of key holds
+// a quote or a line break, which cannot be written to a config or sent
+// to the console without changing the statement.
+func (k *Key) CheckValue() error {
+	return checkArgs(k.name, k.args)
 }
 
 // String returns string representation of value.
 func (k *Key) String() string {
-	val := k.value
+	val := k.Value()
 	if k.s.f.ValueMapper != nil {
 		val = k.s.f.ValueMapper(val)
 	}
@@ -72,7 +295,7 @@ func (k *Key) String() string {
 		}
 
 		// Substitute by new value and take off leading '%(' and trailing ')s'.
-		val = strings.Replace(val, vr, nk.value, -1)
+		val = strings.Replace(val, vr, nk.Value(), -1)
 	}
 	return val
 }
@@ -148,7 +371,7 @@ func (k *Key) Time() (time.Time, error) {
 func (k *Key) MustString(defaultVal string) string {
 	val := k.String()
 	if len(val) == 0 {
-		k.value = defaultVal
+		k.setValue(defaultVal)
 		return defaultVal
 	}
 	return val
@@ -159,7 +382,7 @@ func (k *Key) MustString(defaultVal string) string {
 func (k *Key) MustBool(defaultVal ...bool) bool {
 	val, err := k.Bool()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatBool(defaultVal[0])
+		k.setValue(strconv.FormatBool(defaultVal[0]))
 		return defaultVal[0]
 	}
 	return val
@@ -170,7 +393,7 @@ func (k *Key) MustBool(defaultVal ...bool) bool {
 func (k *Key) MustFloat64(defaultVal ...float64) float64 {
 	val, err := k.Float64()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatFloat(defaultVal[0], 'f', -1, 64)
+		k.setValue(strconv.FormatFloat(defaultVal[0], 'f', -1, 64))
 		return defaultVal[0]
 	}
 	return val
@@ -181,7 +404,7 @@ func (k *Key) MustFloat64(defaultVal ...float64) float64 {
 func (k *Key) MustInt(defaultVal ...int) int {
 	val, err := k.Int()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatInt(int64(defaultVal[0]), 10)
+		k.setValue(strconv.FormatInt(int64(defaultVal[0]), 10))
 		return defaultVal[0]
 	}
 	return val
@@ -192,7 +415,7 @@ func (k *Key) MustInt(defaultVal ...int) int {
 func (k *Key) MustInt64(defaultVal ...int64) int64 {
 	val, err := k.Int64()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatInt(defaultVal[0], 10)
+		k.setValue(strconv.FormatInt(defaultVal[0], 10))
 		return defaultVal[0]
 	}
 	return val
@@ -203,7 +426,7 @@ func (k *Key) MustInt64(defaultVal ...int64) int64 {
 func (k *Key) MustUint(defaultVal ...uint) uint {
 	val, err := k.Uint()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatUint(uint64(defaultVal[0]), 10)
+		k.setValue(strconv.FormatUint(uint64(defaultVal[0]), 10))
 		return defaultVal[0]
 	}
 	return val
@@ -214,7 +437,7 @@ func (k *Key) MustUint(defaultVal ...uint) uint {
 func (k *Key) MustUint64(defaultVal ...uint64) uint64 {
 	val, err := k.Uint64()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = strconv.FormatUint(defaultVal[0], 10)
+		k.setValue(strconv.FormatUint(defaultVal[0], 10))
 		return defaultVal[0]
 	}
 	return val
@@ -225,7 +448,7 @@ func (k *Key) MustUint64(defaultVal ...uint64) uint64 {
 func (k *Key) MustDuration(defaultVal ...time.Duration) time.Duration {
 	val, err := k.Duration()
 	if len(defaultVal) > 0 && err != nil {
-		k.value = defaultVal[0].String()
+		k.setValue(defaultVal[0].String())
 		return defaultVal[0]
 	}
 	return val
@@ -236,7 +459,7 @@ func (k *Key) MustDuration(defaultVal ...time.Duration) time.Duration {
 func (k *Key) MustTimeFormat(format string, defaultVal ...time.Time) time.Time {
 	val, err := k.TimeFormat(format)
 	if len(defaultVal) > 0 && err != nil {
-		k.value = defaultVal[0].Format(format)
+		k.setValue(defaultVal[0].Format(format))
 		return defaultVal[0]
 	}
 	return val
@@ -627,6 +850,40 @@ func (k *Key) SetValue(v string) {
 		defer k.s.f.lock.Unlock()
 	}
 
-	k.value = v
-	k.s.keysHash[k.name] = v
+	k.setValue(v)
+	if k.s.keys[k.name] == k {
+		k.s.keysHash[k.name] = v
+	}
+}
+
+// setValue replaces all arguments of key with given value. The value stays
+// a quoted string if every argument was quoted, so that e.g. a password set
+// to "1234" is not written as a number.
+func (k *Key) setValue(v string) {
+	quoted := len(k.args) > 0
+	for _, arg := range k.args {
+		quoted = quoted && arg.Quoted
+	}
+	k.args = []Arg{{Value: v, Quoted: quoted}}
+}
+
+// SetArgs changes key arguments. Arguments keep their quotes
+// if they were quoted before.
+func (k *Key) SetArgs(args ...string) {
+	if k.s.f.BlockMode {
+		k.s.f.lock.Lock()
+		defer k.s.f.lock.Unlock()
+	}
+
+	newArgs := make([]Arg, len(args))
+	for i := range args {
+		newArgs[i].Value = args[i]
+		if i < len(k.args) {
+			newArgs[i].Quoted = k.args[i].Quoted
+		}
+	}
+	k.args = newArgs
+	if k.s.keys[k.name] == k {
+		k.s.keysHash[k.name] = k.Value()
+	}
 }
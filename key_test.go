@@ -15,6 +15,7 @@
 package csgo_cfg
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
@@ -158,6 +159,158 @@ key2  // comment`
 	})
 }
 
+func Test_Key_Args(t *testing.T) {
+	Convey("Keys with multiple arguments", t, func() {
+		cfg, err := Load([]byte(`bind "MOUSE1" "+attack"
+bind "MOUSE2" "+attack2"
+alias jt "+jump;-attack"
+mp_teamname_1 Team Liquid // home team
+sv_tags "128tick, competitive"`))
+		So(err, ShouldBeNil)
+		sec := cfg.Section("")
+
+		Convey("Get arguments", func() {
+			k := sec.Key("alias")
+			So(k.NumArgs(), ShouldEqual, 2)
+			So(k.Arg(0), ShouldEqual, "jt")
+			So(k.Arg(1), ShouldEqual, "+jump;-attack")
+			So(k.Arg(2), ShouldBeEmpty)
+			So(k.Args(), ShouldResemble, []Arg{{"jt", false}, {"+jump;-attack", true}})
+		})
+
+		Convey("Get value of multiple arguments", func() {
+			So(sec.Key("mp_teamname_1").Value(), ShouldEqual, "Team Liquid")
+			So(sec.Key("mp_teamname_1").Comment, ShouldEqual, "// home team")
+			So(sec.Key("sv_tags").Value(), ShouldEqual, "128tick, competitive")
+		})
+
+		Convey("Get statement", func() {
+			So(sec.Key("alias").Statement(), ShouldEqual, `alias jt "+jump;-attack"`)
+			So(sec.Key("mp_teamname_1").Statement(), ShouldEqual, "mp_teamname_1 Team Liquid")
+		})
+
+		Convey("Get every line of a repeated command", func() {
+			binds := sec.KeysByName("bind")
+			So(binds, ShouldHaveLength, 2)
+			So(binds[0].Arg(0), ShouldEqual, "MOUSE1")
+			So(binds[1].Arg(0), ShouldEqual, "MOUSE2")
+			So(sec.Key("bind").Arg(1), ShouldEqual, "+attack2")
+		})
+
+		Convey("Set arguments", func() {
+			k := sec.KeysByName("bind")[0]
+			k.SetArgs("MOUSE1", "+jump", "extra")
+			So(k.Args(), ShouldResemble, []Arg{{"MOUSE1", true}, {"+jump", true}, {"extra", false}})
+
+			_, err := sec.NewCommand("bind", "SPACE", "+jump")
+			So(err, ShouldBeNil)
+			So(sec.KeysByName("bind"), ShouldHaveLength, 3)
+		})
+	})
+}
+
+func Test_Key_Location(t *testing.T) {
+	Convey("Get location of keys", t, func() {
+		cfg, err := Load([]byte(_CONF_DATA), "testdata/conf.cfg", []byte("  mp_freezetime 15; mp_roundtime 1.92"))
+		So(err, ShouldBeNil)
+		sec := cfg.Section("")
+
+		k := sec.Key("mp_autokick")
+		So(k.Source(), ShouldBeEmpty)
+		So(k.Line(), ShouldEqual, 11)
+		So(k.Column(), ShouldEqual, 1)
+		So(k.Location().String(), ShouldEqual, "<source 0>:11")
+
+		k = sec.Key("mp_roundtime")
+		So(k.Location(), ShouldResemble, Location{Index: 2, Line: 1, Column: 21})
+
+		Convey("Get overridden definitions", func() {
+			k := sec.Key("ammo_grenade_limit_total")
+			So(k.Location().String(), ShouldEqual, "testdata/conf.cfg:7")
+
+			history := k.History()
+			So(history, ShouldHaveLength, 1)
+			So(history[0].Value(), ShouldEqual, "4")
+			So(history[0].Location().String(), ShouldEqual, "testdata/conf.cfg:3")
+			So(sec.Key("ammo_grenade_limit_default").History(), ShouldBeEmpty)
+		})
+
+		Convey("Keys created in memory have no location", func() {
+			k, err := sec.NewKey("sv_cheats", "0")
+			So(err, ShouldBeNil)
+			So(k.Location(), ShouldResemble, Location{})
+		})
+	})
+}
+
+func Test_Key_Kind(t *testing.T) {
+	Convey("Infer kind of values", t, func() {
+		cfg, err := Load([]byte(`bot_kick
+mp_friendlyfire 1
+mp_maxmoney -1
+mp_roundtime 1.92
+sv_gravity .5e3
+map de_dust2
+cl_crosshaircolor_hex 0xFF8000
+sv_password "1234"
+mp_teamname_1 Team Liquid`))
+		So(err, ShouldBeNil)
+		sec := cfg.Section("")
+
+		So(sec.Key("bot_kick").Kind(), ShouldEqual, KindNone)
+		So(sec.Key("mp_friendlyfire").Kind(), ShouldEqual, KindBool)
+		So(sec.Key("mp_maxmoney").Kind(), ShouldEqual, KindInt)
+		So(sec.Key("mp_roundtime").Kind(), ShouldEqual, KindFloat)
+		So(sec.Key("sv_gravity").Kind(), ShouldEqual, KindFloat)
+		So(sec.Key("map").Kind(), ShouldEqual, KindIdent)
+		So(sec.Key("cl_crosshaircolor_hex").Kind(), ShouldEqual, KindIdent)
+		So(sec.Key("sv_password").Kind(), ShouldEqual, KindString)
+		So(sec.Key("mp_teamname_1").Kind(), ShouldEqual, KindString)
+		So(KindFloat.String(), ShouldEqual, "float")
+
+		for _, val := range []string{"inf", "1_000", "1e", "-", ".", "1.2.3"} {
+			So(inferKind(val), ShouldEqual, KindIdent)
+		}
+
+		Convey("Keep kind of quoted strings when set", func() {
+			sec.Key("sv_password").SetValue("5678")
+			So(sec.Key("sv_password").Kind(), ShouldEqual, KindString)
+
+			sec.Key("mp_roundtime").SetValue("2")
+			So(sec.Key("mp_roundtime").Kind(), ShouldEqual, KindInt)
+		})
+
+		Convey("Values that are written quoted are strings", func() {
+			k, err := sec.NewKey("hostname", "My Server")
+			So(err, ShouldBeNil)
+			So(k.Kind(), ShouldEqual, KindString)
+
+			sec.Key("map").SetValue("")
+			So(sec.Key("map").Kind(), ShouldEqual, KindString)
+
+			var buf bytes.Buffer
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			again, err := Load(buf.Bytes())
+			So(err, ShouldBeNil)
+			So(again.Section("").Key("hostname").Kind(), ShouldEqual, KindString)
+		})
+
+		Convey("Keep quotes of numbers with minimal quoting", func() {
+			defer func() { ValueQuoting = QuoteKeep }()
+			ValueQuoting = QuoteMinimal
+
+			cfg, err := Load([]byte(`sv_password "1234"` + LineBreak + `hostname "Server"` + LineBreak))
+			So(err, ShouldBeNil)
+
+			var buf bytes.Buffer
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, `sv_password "1234"`+LineBreak+`hostname    Server`+LineBreak)
+		})
+	})
+}
+
 func newTestFile(block bool) *File {
 	c, _ := Load([]byte(_CONF_DATA))
 	c.BlockMode = block
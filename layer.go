@@ -0,0 +1,243 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Layer represents a config in a Layered stack.
+type Layer struct {
+	Name string
+	File *File
+}
+
+// LayerKey represents a key set by a layer.
+type LayerKey struct {
+	Layer string
+	*Key
+}
+
+// Layered represents a stack of configs, e.g. defaults, gamemode, league,
+// server-local and runtime overrides, where keys of later layers take
+// precedence. Unlike a File of multiple data sources, every layer is kept
+// on its own, so it can be replaced or reloaded, and values can be traced
+// to the layer that set them.
+type Layered struct {
+	lock   sync.RWMutex
+	layers []*Layer
+
+	// Effective view of keys, computed on first use after layers are changed.
+	names []string
+	keys  map[string]*LayerKey
+}
+
+// NewLayered returns a stack of given layers, from lowest to highest precedence.
+func NewLayered(layers ...*Layer) *Layered {
+	l := &Layered{}
+	for _, layer := range layers {
+		l.Push(layer.Name, layer.File)
+	}
+	return l
+}
+
+// Push adds a layer on top of the stack, which takes precedence over all others.
+func (l *Layered) Push(name string, f *File) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.layers = append(l.layers, &Layer{name, f})
+	l.keys = nil
+}
+
+// index returns position of named layer, or -1 if there is no such layer.
+func (l *Layered) index(name string) int {
+	for i := range l.layers {
+		if l.layers[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Layer returns layer by given name, or nil if there is no such layer.
+func (l *Layered) Layer(name string) *Layer {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if i := l.index(name); i >= 0 {
+		return l.layers[i]
+	}
+	return nil
+}
+
+// Layers returns list of layers from lowest to highest precedence.
+func (l *Layered) Layers() []*Layer {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	layers := make([]*Layer, len(l.layers))
+	copy(layers, l.layers)
+	return layers
+}
+
+// Replace replaces content of named layer, keeping its precedence.
+func (l *Layered) Replace(name string, f *File) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	i := l.index(name)
+	if i < 0 {
+		return fmt.Errorf("error replacing layer: layer '%s' not exists", name)
+	}
+	l.layers[i] = &Layer{name, f}
+	l.keys = nil
+	return nil
+}
+
+// Remove removes named layer from the stack.
+func (l *Layered) Remove(name string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	i := l.index(name)
+	if i < 0 {
+		return fmt.Errorf("error removing layer: layer '%s' not exists", name)
+	}
+	l.layers = append(l.layers[:i], l.layers[i+1:]...)
+	l.keys = nil
+	return nil
+}
+
+// Reload reloads named layer from its data sources.
+func (l *Layered) Reload(name string) error {
+	layer := l.Layer(name)
+	if layer == nil {
+		return fmt.Errorf("error reloading layer: layer '%s' not exists", name)
+	}
+	defer l.Invalidate()
+	return layer.File.Reload()
+}
+
+// ReloadAll reloads every layer from its data sources.
+func (l *Layered) ReloadAll() error {
+	defer l.Invalidate()
+	for _, layer := range l.Layers() {
+		if err := layer.File.Reload(); err != nil {
+			return fmt.Errorf("error reloading layer '%s': %v", layer.Name, err)
+		}
+	}
+	return nil
+}
+
+// Invalidate drops the effective view, so that changes made to files
+// of layers directly, e.g. by Key.SetValue, are seen.
+func (l *Layered) Invalidate() {
+	l.lock.Lock()
+	l.keys = nil
+	l.lock.Unlock()
+}
+
+// Without returns a stack of the same layers except the named ones,
+// to see what values would be without them.
+func (l *Layered) Without(names ...string) *Layered {
+	nl := &Layered{}
+	for _, layer := range l.Layers() {
+		if !inSlice(layer.Name, names) {
+			nl.layers = append(nl.layers, layer)
+		}
+	}
+	return nl
+}
+
+// view returns the effective view of keys, computing it if necessary.
+func (l *Layered) view() ([]string, map[string]*LayerKey) {
+	l.lock.RLock()
+	if l.keys != nil {
+		defer l.lock.RUnlock()
+		return l.names, l.keys
+	}
+	l.lock.RUnlock()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.keys == nil {
+		l.names = nil
+		l.keys = make(map[string]*LayerKey)
+		for _, layer := range l.layers {
+			names, _, keys := layer.File.effectiveKeys()
+			for _, name := range names {
+				if _, ok := l.keys[name]; !ok {
+					l.names = append(l.names, name)
+				}
+				l.keys[name] = &LayerKey{layer.Name, keys[name]}
+			}
+		}
+	}
+	return l.names, l.keys
+}
+
+// Key returns the effective key with given name and the layer that sets it.
+func (l *Layered) Key(name string) (*LayerKey, error) {
+	_, keys := l.view()
+	k := keys[name]
+	if k == nil {
+		return nil, fmt.Errorf("error when getting layered key: key '%s' not exists", name)
+	}
+	return k, nil
+}
+
+// Value returns effective value of given key, or an empty string if it is not set.
+func (l *Layered) Value(name string) string {
+	k, err := l.Key(name)
+	if err != nil {
+		return ""
+	}
+	return k.Value()
+}
+
+// KeyStrings returns names of keys set by any layer, in the order they are first set.
+func (l *Layered) KeyStrings() []string {
+	names, _ := l.view()
+	list := make([]string, len(names))
+	copy(list, names)
+	return list
+}
+
+// KeysHash returns effective values of all keys by name.
+func (l *Layered) KeysHash() map[string]string {
+	_, keys := l.view()
+	hash := make(map[string]string, len(keys))
+	for name, k := range keys {
+		hash[name] = k.Value()
+	}
+	return hash
+}
+
+// Explain returns every statement that sets given key, from the lowest
+// layer to the highest, in the order they run within each layer. The
+// last one is the effective key, and the others are overridden by it.
+func (l *Layered) Explain(name string) []*LayerKey {
+	var chain []*LayerKey
+	for _, layer := range l.Layers() {
+		layer.File.walkKeys(func(k *Key) {
+			if k.name == name {
+				chain = append(chain, &LayerKey{layer.Name, k})
+			}
+		})
+	}
+	return chain
+}
@@ -0,0 +1,92 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Layered(t *testing.T) {
+	Convey("Stack config layers", t, func() {
+		defaults, err := Load([]byte("mp_maxrounds 30\nmp_freezetime 15\nsv_cheats 0"))
+		So(err, ShouldBeNil)
+		gamemode, err := LoadSources(LoadOptions{ResolveExec: true}, "testdata/exec/gamemode_competitive_server.cfg")
+		So(err, ShouldBeNil)
+		local, err := Load([]byte("mp_freezetime 10\nhostname \"My Server\""))
+		So(err, ShouldBeNil)
+
+		l := NewLayered(&Layer{"defaults", defaults}, &Layer{"gamemode", gamemode})
+		l.Push("local", local)
+		So(l.Layers(), ShouldHaveLength, 3)
+
+		k, err := l.Key("mp_maxrounds")
+		So(err, ShouldBeNil)
+		So(k.Layer, ShouldEqual, "gamemode")
+		So(k.Value(), ShouldEqual, "30")
+		So(k.Line(), ShouldEqual, 1)
+		So(l.Value("mp_freezetime"), ShouldEqual, "10")
+		So(l.KeyStrings()[:3], ShouldResemble, []string{"mp_maxrounds", "mp_freezetime", "sv_cheats"})
+		So(l.KeysHash()["hostname"], ShouldEqual, "My Server")
+
+		_, err = l.Key("mp_halftime")
+		So(err, ShouldNotBeNil)
+
+		Convey("Explain a key", func() {
+			chain := l.Explain("mp_maxrounds")
+			So(chain, ShouldHaveLength, 3)
+			So(chain[0].Layer, ShouldEqual, "defaults")
+			So(chain[1].Layer, ShouldEqual, "gamemode")
+			So(chain[1].Value(), ShouldEqual, "24")
+			So(chain[2].Layer, ShouldEqual, "gamemode")
+			So(chain[2].Source(), ShouldEndWith, "esl5on5.cfg")
+		})
+
+		Convey("Query without a layer", func() {
+			So(l.Without("local").Value("mp_freezetime"), ShouldEqual, "15")
+			So(l.Without("gamemode", "local").Value("mp_maxrounds"), ShouldEqual, "30")
+			So(l.Value("mp_freezetime"), ShouldEqual, "10")
+		})
+
+		Convey("Replace and remove layers", func() {
+			runtime, err := Load([]byte("mp_freezetime 5"))
+			So(err, ShouldBeNil)
+			l.Push("runtime", runtime)
+			So(l.Value("mp_freezetime"), ShouldEqual, "5")
+
+			overrides, err := Load([]byte("mp_freezetime 3"))
+			So(err, ShouldBeNil)
+			So(l.Replace("runtime", overrides), ShouldBeNil)
+			So(l.Value("mp_freezetime"), ShouldEqual, "3")
+			So(l.Replace("404", overrides), ShouldNotBeNil)
+
+			So(l.Remove("runtime"), ShouldBeNil)
+			So(l.Value("mp_freezetime"), ShouldEqual, "10")
+			So(l.Layer("runtime"), ShouldBeNil)
+		})
+
+		Convey("Reload a layer", func() {
+			local.Section("").Key("mp_freezetime").SetValue("12")
+			l.Invalidate()
+			So(l.Value("mp_freezetime"), ShouldEqual, "12")
+
+			So(l.Reload("local"), ShouldBeNil)
+			So(l.Value("mp_freezetime"), ShouldEqual, "10")
+			So(l.ReloadAll(), ShouldBeNil)
+			So(l.Reload("404"), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// WriteText writes diagnostics one per line, in "file:line:column: severity: message [rule]" format.
+func WriteText(w io.Writer, diags []*Diagnostic) error {
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonDiagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+}
+
+// WriteJSON writes diagnostics as a JSON array.
+func WriteJSON(w io.Writer, diags []*Diagnostic) error {
+	out := make([]jsonDiagnostic, len(diags))
+	for i, d := range diags {
+		out[i] = jsonDiagnostic{
+			Rule:     d.Rule,
+			Severity: d.Severity,
+			Message:  d.Message,
+			File:     d.Location.Source,
+			Line:     d.Line,
+			Column:   d.Column,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// Types below follow the SARIF 2.1.0 format, as far as it is used here.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+		DefaultConfig    struct {
+			Level Severity `json:"level"`
+		} `json:"defaultConfiguration"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     Severity        `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine   int `json:"startLine"`
+				StartColumn int `json:"startColumn,omitempty"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	}
+)
+
+// WriteSARIF writes diagnostics in SARIF 2.1.0 format for code scanning tools,
+// with given rules described as the ones of tool name.
+func WriteSARIF(w io.Writer, name string, rules []*Rule, diags []*Diagnostic) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: name, Rules: make([]sarifRule, len(rules))}},
+		Results: make([]sarifResult, 0, len(diags)),
+	}
+	for i, r := range rules {
+		run.Tool.Driver.Rules[i].ID = r.ID
+		run.Tool.Driver.Rules[i].ShortDescription.Text = r.Description
+		run.Tool.Driver.Rules[i].DefaultConfig.Level = r.Severity
+	}
+
+	for _, d := range diags {
+		res := sarifResult{
+			RuleID:  d.Rule,
+			Level:   d.Severity,
+			Message: sarifMessage{d.Message},
+		}
+		if len(d.Location.Source) > 0 {
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(d.Location.Source)
+			loc.PhysicalLocation.Region.StartLine = d.Line
+			loc.PhysicalLocation.Region.StartColumn = d.Column
+			res.Locations = []sarifLocation{loc}
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
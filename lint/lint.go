@@ -0,0 +1,204 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package lint checks CS:GO configs for likely mistakes with a set of rules.
+//
+// A diagnostic is suppressed by a comment naming its rule on the line it is
+// reported at. Duplicate keys are reported at the value that has no effect,
+// so "ammo_grenade_limit_total 4 // cfglint:ignore dup-key" followed by
+// "ammo_grenade_limit_total 5" reports nothing. Without rule IDs, every
+// diagnostic on the line is suppressed.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// Severity represents how serious a diagnostic is, with the same names
+// as SARIF levels.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Directive in comments that suppresses diagnostics on the line.
+const _IGNORE_DIRECTIVE = "cfglint:ignore"
+
+// Diagnostic represents a problem found by a rule.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	cfg.Location
+	// Key is the statement the problem is found at, or nil
+	// for problems such as parse errors.
+	Key *cfg.Key
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s [%s]", d.Location, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Rule represents a check of configs.
+type Rule struct {
+	// ID is the short name used in output and suppression comments, e.g. "dup-key".
+	ID          string
+	Description string
+	// Severity of diagnostics reported by the rule.
+	Severity Severity
+	Check    func(p *Pass)
+}
+
+// Pass holds what a rule checks, and collects what it reports.
+type Pass struct {
+	File *cfg.File
+	// Schema is the set of convars the config is checked against.
+	Schema *cfg.Schema
+	// Statements is every key of File in the order the console runs them,
+	// following "exec" statements.
+	Statements []*cfg.Key
+
+	rule  *Rule
+	diags []*Diagnostic
+}
+
+// Reportf reports a problem at given key.
+func (p *Pass) Reportf(k *cfg.Key, format string, args ...interface{}) {
+	p.diags = append(p.diags, &Diagnostic{
+		Rule:     p.rule.ID,
+		Severity: p.rule.Severity,
+		Message:  fmt.Sprintf(format, args...),
+		Location: k.Location(),
+		Key:      k,
+	})
+}
+
+// Run checks the config with given rules against schema,
+// and returns diagnostics that are not suppressed, ordered by location.
+func Run(f *cfg.File, schema *cfg.Schema, rules []*Rule) []*Diagnostic {
+	if schema == nil {
+		schema = cfg.DefaultSchema()
+	}
+
+	stmts := f.Statements()
+	var diags []*Diagnostic
+	for _, r := range rules {
+		p := &Pass{
+			File:       f,
+			Schema:     schema,
+			Statements: stmts,
+			rule:       r,
+		}
+		r.Check(p)
+		diags = append(diags, p.diags...)
+	}
+
+	ignores := ignoredRules(stmts)
+	kept := diags[:0]
+	for _, d := range diags {
+		if !isIgnored(ignores, d) {
+			kept = append(kept, d)
+		}
+	}
+	SortDiagnostics(kept)
+	return kept
+}
+
+// ParseErrorRule describes diagnostics of errors found when loading configs,
+// which are not checked by running rules.
+var ParseErrorRule = &Rule{
+	ID:          "parse-error",
+	Description: "Config cannot be parsed or loaded.",
+	Severity:    SeverityError,
+	Check:       func(*Pass) {},
+}
+
+// ParseErrorDiagnostics returns diagnostics of errors collected
+// with cfg.LoadOptions.CollectErrors.
+func ParseErrorDiagnostics(errs cfg.ParseErrors) []*Diagnostic {
+	diags := make([]*Diagnostic, len(errs))
+	for i, err := range errs {
+		diags[i] = &Diagnostic{
+			Rule:     ParseErrorRule.ID,
+			Severity: ParseErrorRule.Severity,
+			Message:  err.Err.Error(),
+			Location: err.Location,
+		}
+	}
+	return diags
+}
+
+// SortDiagnostics sorts diagnostics by source, line and column.
+func SortDiagnostics(diags []*Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i], diags[j]
+		switch {
+		case a.Source != b.Source:
+			return a.Source < b.Source
+		case a.Index != b.Index:
+			return a.Index < b.Index
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// lineOf returns location of the line a diagnostic is on.
+func lineOf(loc cfg.Location) cfg.Location {
+	loc.Column = 0
+	return loc
+}
+
+// ignoredRules returns rule IDs named by suppression comments by line.
+// An empty list suppresses every rule.
+func ignoredRules(stmts []*cfg.Key) map[cfg.Location][]string {
+	ignores := make(map[cfg.Location][]string)
+	for _, k := range stmts {
+		i := strings.Index(k.Comment, _IGNORE_DIRECTIVE)
+		if i < 0 {
+			continue
+		}
+		ids := strings.FieldsFunc(k.Comment[i+len(_IGNORE_DIRECTIVE):], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if ids == nil {
+			ids = []string{}
+		}
+		ignores[lineOf(k.Location())] = ids
+	}
+	return ignores
+}
+
+func isIgnored(ignores map[cfg.Location][]string, d *Diagnostic) bool {
+	ids, ok := ignores[lineOf(d.Location)]
+	if !ok {
+		return false
+	} else if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if id == d.Rule {
+			return true
+		}
+	}
+	return false
+}
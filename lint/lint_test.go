@@ -0,0 +1,153 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+const _CONF_DATA = `hostname Test
+mp_roundtime 1.92
+sv_cheats 0
+noclip // cfglint:ignore unknown-cvar
+mp_freezetime 5; mp_freezetime 15
+mp_maxrounds 30 // cfglint:ignore
+mp_maxrounds 24
+mp_friendlyfire 2
+not_a_cvar 1
+sv_cheats 1
+noclip
+`
+
+func loadRules(t *testing.T, data string, rules ...*Rule) []*Diagnostic {
+	f, err := cfg.Load([]byte(data))
+	So(err, ShouldBeNil)
+	return Run(f, nil, rules)
+}
+
+func Test_Rules(t *testing.T) {
+	Convey("Find duplicate keys", t, func() {
+		f, err := cfg.Load("../testdata/conf.cfg")
+		So(err, ShouldBeNil)
+
+		diags := Run(f, nil, []*Rule{DupKey})
+		So(diags, ShouldHaveLength, 1)
+		So(diags[0].Key.Name(), ShouldEqual, "ammo_grenade_limit_total")
+		So(diags[0].Line, ShouldEqual, 3)
+		So(diags[0].String(), ShouldEqual, "../testdata/conf.cfg:3:1: warning: "+
+			"'ammo_grenade_limit_total' is set again at ../testdata/conf.cfg:7, this value has no effect [dup-key]")
+
+		Convey("Suppress as in package documentation", func() {
+			diags := loadRules(t, "ammo_grenade_limit_total 4 // cfglint:ignore dup-key\nammo_grenade_limit_total 5\n", DupKey)
+			So(diags, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Run every rule", t, func() {
+		diags := loadRules(t, _CONF_DATA, DefaultRules()...)
+
+		var got []string
+		for _, d := range diags {
+			got = append(got, d.Rule)
+		}
+		So(got, ShouldResemble, []string{
+			"unquoted-string", // hostname Test
+			"dup-key",         // sv_cheats 0
+			"cheat-cvar",      // noclip
+			"dup-key",         // mp_freezetime 5
+			"bad-value",       // mp_friendlyfire 2
+			"unknown-cvar",    // not_a_cvar 1
+		})
+		So(diags[3].Column, ShouldEqual, 1)
+		So(diags[4].Message, ShouldEqual, "mp_friendlyfire: value '2' is not bool")
+	})
+}
+
+func Test_Formats(t *testing.T) {
+	Convey("Write diagnostics", t, func() {
+		diags := loadRules(t, "sv_cheats 0\nnoclip\n", CheatCvar)
+		So(diags, ShouldHaveLength, 1)
+
+		Convey("In text format", func() {
+			var buf bytes.Buffer
+			So(WriteText(&buf, diags), ShouldBeNil)
+			So(buf.String(), ShouldEqual, "<source 0>:2:1: warning: 'noclip' is cheat protected and sv_cheats is not enabled [cheat-cvar]\n")
+		})
+
+		Convey("In JSON format", func() {
+			var buf bytes.Buffer
+			So(WriteJSON(&buf, diags), ShouldBeNil)
+
+			var out []map[string]interface{}
+			So(json.Unmarshal(buf.Bytes(), &out), ShouldBeNil)
+			So(out, ShouldHaveLength, 1)
+			So(out[0]["rule"], ShouldEqual, "cheat-cvar")
+			So(out[0]["line"], ShouldEqual, 2)
+		})
+
+		Convey("In SARIF format", func() {
+			diags[0].Source = "server.cfg"
+
+			var buf bytes.Buffer
+			So(WriteSARIF(&buf, "cfglint", DefaultRules(), diags), ShouldBeNil)
+
+			var out struct {
+				Version string
+				Runs    []struct {
+					Tool struct {
+						Driver struct {
+							Rules []struct{ ID string }
+						}
+					}
+					Results []struct {
+						RuleID    string
+						Level     string
+						Locations []struct {
+							PhysicalLocation struct {
+								ArtifactLocation struct{ URI string }
+								Region           struct{ StartLine int }
+							}
+						}
+					}
+				}
+			}
+			So(json.Unmarshal(buf.Bytes(), &out), ShouldBeNil)
+			So(out.Version, ShouldEqual, "2.1.0")
+			So(out.Runs[0].Tool.Driver.Rules, ShouldHaveLength, len(DefaultRules()))
+			res := out.Runs[0].Results[0]
+			So(res.RuleID, ShouldEqual, "cheat-cvar")
+			So(res.Level, ShouldEqual, "warning")
+			So(res.Locations[0].PhysicalLocation.ArtifactLocation.URI, ShouldEqual, "server.cfg")
+			So(res.Locations[0].PhysicalLocation.Region.StartLine, ShouldEqual, 2)
+		})
+	})
+
+	Convey("Report parse errors", t, func() {
+		_, err := cfg.LoadSources(cfg.LoadOptions{CollectErrors: true}, []byte("hostname \"test\nbot_kick"))
+		errs, ok := err.(cfg.ParseErrors)
+		So(ok, ShouldBeTrue)
+
+		diags := ParseErrorDiagnostics(errs)
+		So(diags, ShouldHaveLength, 1)
+		So(diags[0].Rule, ShouldEqual, "parse-error")
+		So(diags[0].Line, ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package lint
+
+import (
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+var (
+	// DupKey reports convars that are set again before the value
+	// could take effect.
+	DupKey = &Rule{
+		ID:          "dup-key",
+		Description: "Convar is set again later, so this value has no effect.",
+		Severity:    SeverityWarning,
+		Check:       checkDupKey,
+	}
+
+	// UnknownCvar reports names that are not in the schema.
+	UnknownCvar = &Rule{
+		ID:          "unknown-cvar",
+		Description: "Name is not a known convar or command.",
+		Severity:    SeverityWarning,
+		Check:       checkUnknownCvar,
+	}
+
+	// CheatCvar reports cheat-protected convars and commands used while
+	// "sv_cheats" is not enabled.
+	CheatCvar = &Rule{
+		ID:          "cheat-cvar",
+		Description: "Convar requires sv_cheats 1 and is ignored otherwise.",
+		Severity:    SeverityWarning,
+		Check:       checkCheatCvar,
+	}
+
+	// BadValue reports values of wrong type or out of range.
+	BadValue = &Rule{
+		ID:          "bad-value",
+		Description: "Value is of wrong type or out of range.",
+		Severity:    SeverityError,
+		Check:       checkBadValue,
+	}
+
+	// UnquotedString reports values of string convars not wrapped in "".
+	UnquotedString = &Rule{
+		ID:          "unquoted-string",
+		Description: "String value should be wrapped in quotes.",
+		Severity:    SeverityNote,
+		Check:       checkUnquotedString,
+	}
+)
+
+// DefaultRules returns all built-in rules.
+func DefaultRules() []*Rule {
+	return []*Rule{DupKey, UnknownCvar, CheatCvar, BadValue, UnquotedString}
+}
+
+// isCommand returns true if name is a command, which may run many times.
+func isCommand(schema *cfg.Schema, name string) bool {
+	cv := schema.Convar(name)
+	return cv != nil && cv.Type == cfg.ConvarCommand
+}
+
+func checkDupKey(p *Pass) {
+	for _, k := range p.Statements {
+		hist := k.History()
+		if len(hist) == 0 || !k.HasValue() || isCommand(p.Schema, k.Name()) {
+			continue
+		}
+
+		// Report the last definition that is overridden, earlier ones
+		// are reported by the keys that override them.
+		prev := hist[len(hist)-1]
+		if prev.HasValue() {
+			p.Reportf(prev, "'%s' is set again at %s, this value has no effect", prev.Name(), k.Location())
+		}
+	}
+}
+
+func checkUnknownCvar(p *Pass) {
+	for _, k := range p.Statements {
+		if p.Schema.Convar(k.Name()) == nil {
+			p.Reportf(k, "unknown convar '%s'", k.Name())
+		}
+	}
+}
+
+func checkCheatCvar(p *Pass) {
+	cheats := false
+	for _, k := range p.Statements {
+		if strings.EqualFold(k.Name(), "sv_cheats") && k.HasValue() {
+			cheats = k.Value() != "0"
+			continue
+		}
+
+		cv := p.Schema.Convar(k.Name())
+		if !cheats && cv != nil && cv.HasFlag(cfg.FCVAR_CHEAT) {
+			p.Reportf(k, "'%s' is cheat protected and sv_cheats is not enabled", k.Name())
+		}
+	}
+}
+
+func checkBadValue(p *Pass) {
+	for _, err := range p.File.Validate(p.Schema) {
+		if err.Kind == cfg.ErrKindUnknownConvar {
+			continue
+		}
+		p.Reportf(err.Key, "%s", strings.TrimPrefix(err.Error(), err.Key.Location().String()+": "))
+	}
+}
+
+func checkUnquotedString(p *Pass) {
+	for _, k := range p.Statements {
+		cv := p.Schema.Convar(k.Name())
+		if cv == nil || cv.Type != cfg.ConvarString {
+			continue
+		}
+		for _, arg := range k.Args() {
+			if !arg.Quoted {
+				p.Reportf(k, "value of '%s' should be quoted", k.Name())
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"strings"
+)
+
+// Conflict represents a statement that is changed differently by both sides
+// of a merge.
+type Conflict struct {
+	// Name is the statement as written, e.g. "sv_cheats" or "bind MOUSE1".
+	Name string
+	// Base, Ours and Theirs are the last lines of the statement on each
+	// side, or nil where it is absent.
+	Base, Ours, Theirs *Key
+
+	// Statement ID and all lines of the statement on both sides,
+	// for conflict markers.
+	id           string
+	ours, theirs []*Key
+}
+
+// formatStatement returns key as a single statement, e.g. `hostname "Match" // name`.
+func formatStatement(k *Key) string {
+	if val := formatKeyValue(k); len(val) > 0 {
+		return k.name + " " + val
+	}
+	return k.name
+}
+
+// markers returns both sides of the conflict wrapped in conflict markers.
+func (c *Conflict) markers() string {
+	buf := make([]string, 0, 5)
+	buf = append(buf, "<<<<<<< ours")
+	for _, k := range c.ours {
+		buf = append(buf, formatStatement(k))
+	}
+	buf = append(buf, "=======")
+	for _, k := range c.theirs {
+		buf = append(buf, formatStatement(k))
+	}
+	buf = append(buf, ">>>>>>> theirs")
+	return strings.Join(buf, LineBreak) + LineBreak
+}
+
+// statementLines returns statement IDs of keys of section in the order
+// they first appear, and all lines of each statement in order.
+func (s *Section) statementLines() ([]string, map[string][]*Key) {
+	var ids []string
+	lines := make(map[string][]*Key)
+	for _, n := range s.nodes {
+		if n.typ != _TOKEN_KEY {
+			continue
+		}
+		id := statementID(n.key)
+		if _, ok := lines[id]; !ok {
+			ids = append(ids, id)
+		}
+		lines[id] = append(lines[id], n.key)
+	}
+	return ids, lines
+}
+
+// sameLines returns true if both statements have the same number of lines,
+// setting the same values in order. Absent statements have no lines.
+func sameLines(a, b []*Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Value() != b[i].Value() {
+			return false
+		}
+	}
+	return true
+}
+
+// lastKey returns the last line of a statement, or nil if it is absent.
+func lastKey(lines []*Key) *Key {
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines[len(lines)-1]
+}
+
+// copyArgs returns a copy of args so that merged keys do not share them.
+func copyArgs(args []Arg) []Arg {
+	cp := make([]Arg, len(args))
+	copy(cp, args)
+	return cp
+}
+
+// insertNode inserts node after the last line of statement prevID, keeping
+// statements that share that line together, or at the end of section if
+// there is no such statement.
+func (s *Section) insertNode(n *node, prevID string) {
+	at := len(s.nodes)
+	for i := len(s.nodes) - 1; i >= 0; i-- {
+		cur := s.nodes[i]
+		if (cur.typ == _TOKEN_KEY && statementID(cur.key) == prevID) ||
+			(cur.typ == _TOKEN_CONFLICT && cur.conflict.id == prevID) {
+			at = i + 1
+			for at < len(s.nodes) && s.nodes[at].inline {
+				at++
+			}
+			break
+		}
+	}
+
+	s.nodes = append(s.nodes, nil)
+	copy(s.nodes[at+1:], s.nodes[at:])
+	s.nodes[at] = n
+}
+
+// replaceNode replaces line of given key with n.
+func (s *Section) replaceNode(k *Key, n *node) {
+	for i := range s.nodes {
+		if s.nodes[i].key == k {
+			s.nodes[i] = n
+			// Conflict markers take whole lines.
+			if i+1 < len(s.nodes) {
+				s.nodes[i+1].inline = false
+			}
+			return
+		}
+	}
+}
+
+// reindex rebuilds keys, their order and history from lines of section
+// after they are changed directly.
+func (s *Section) reindex() {
+	s.keys = make(map[string]*Key)
+	s.keyList = s.keyList[:0]
+	s.keysHash = make(map[string]string)
+	for _, n := range s.nodes {
+		if n.typ != _TOKEN_KEY {
+			continue
+		}
+		k := n.key
+		if prev, ok := s.keys[k.name]; !ok {
+			s.keyList = append(s.keyList, k.name)
+			k.history = nil
+		} else {
+			k.history = append(prev.History(), prev)
+		}
+		s.keys[k.name] = k
+		s.keysHash[k.name] = k.Value()
+	}
+}
+
+// Merge performs a statement-level three-way merge of configs ours and
+// theirs, which are both changed from base. Changes made by only one side
+// are applied to a copy of ours, keeping its layout and comments.
+// Statements changed differently by both sides are conflicts, which are
+// returned and written by WriteTo between conflict markers in place of the
+// statement.
+//
+// Statements are keys of the same name regardless of case, or of the same
+// name and first argument for "bind" and "alias", e.g. "bind MOUSE1". All
+// lines of a statement are compared in order, so a change to any line of a
+// repeated key is merged or reported. Lines of a statement are taken from
+// theirs one by one, and a statement whose number of lines differs between
+// ours and theirs is a conflict unless only one side changed it from base
+// and the other removed or added it. Files loaded by "exec" statements are
+// not followed.
+func Merge(base, ours, theirs *File) (*File, []*Conflict) {
+	baseSec, oursSec, theirsSec := base.Section(DEFAULT_SECTION), ours.Section(DEFAULT_SECTION), theirs.Section(DEFAULT_SECTION)
+
+	f := Empty()
+	f.options = ours.options
+	f.bom = ours.bom
+	sec := f.Section(DEFAULT_SECTION)
+	// Lines of the merged section by statement, as copied from ours.
+	merged := make(map[string][]*Key)
+	for _, n := range oursSec.nodes {
+		switch n.typ {
+		case _TOKEN_KEY:
+			k := sec.appendKey(n.key.name, copyArgs(n.key.args), n.inline)
+			k.Comment = n.key.Comment
+			k.loc = n.key.loc
+			// Lines of ours that are not changed by the merge are kept as is.
+			last := sec.nodes[len(sec.nodes)-1]
+			last.line, last.raw = n.line, n.raw
+			merged[statementID(k)] = append(merged[statementID(k)], k)
+		default:
+			cp := *n
+			sec.nodes = append(sec.nodes, &cp)
+		}
+	}
+
+	_, baseLines := baseSec.statementLines()
+	ids, oursLines := oursSec.statementLines()
+	theirsIDs, theirsLines := theirsSec.statementLines()
+	for _, id := range theirsIDs {
+		if _, ok := oursLines[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	// Statements that come before each statement in theirs, to place
+	// statements added by theirs next to the same neighbour.
+	prevIDs := make(map[string]string)
+	prev := ""
+	for _, id := range theirsIDs {
+		prevIDs[id] = prev
+		prev = id
+	}
+
+	var conflicts []*Conflict
+	for _, id := range ids {
+		b, o, t := baseLines[id], oursLines[id], theirsLines[id]
+		switch {
+		case sameLines(o, t), sameLines(t, b):
+			// Nothing to take from theirs.
+		case sameLines(o, b) && len(t) == 0:
+			for _, k := range merged[id] {
+				sec.deleteNodes(func(nk *Key) bool { return nk == k })
+			}
+		case sameLines(o, b) && len(o) == 0:
+			after := prevIDs[id]
+			for _, tk := range t {
+				k := &Key{s: sec, name: tk.name, args: copyArgs(tk.args), loc: tk.loc, Comment: tk.Comment}
+				sec.insertNode(&node{typ: _TOKEN_KEY, key: k}, after)
+				after = id
+			}
+		case sameLines(o, b) && len(o) == len(t):
+			for i, k := range merged[id] {
+				k.args = copyArgs(t[i].args)
+				if o[i].Comment == b[i].Comment {
+					k.Comment = t[i].Comment
+				}
+			}
+		default:
+			named := lastKey(o)
+			if named == nil {
+				named = lastKey(t)
+			}
+			c := &Conflict{
+				Name:   statementName(named),
+				Base:   lastKey(b),
+				Ours:   lastKey(o),
+				Theirs: lastKey(t),
+				id:     id,
+				ours:   o,
+				theirs: t,
+			}
+			conflicts = append(conflicts, c)
+
+			n := &node{typ: _TOKEN_CONFLICT, conflict: c}
+			if lines := merged[id]; len(lines) > 0 {
+				// Markers hold all lines of both sides in place of the first one.
+				sec.replaceNode(lines[0], n)
+				for _, k := range lines[1:] {
+					sec.deleteNodes(func(nk *Key) bool { return nk == k })
+				}
+			} else {
+				sec.insertNode(n, prevIDs[id])
+			}
+		}
+	}
+	sec.reindex()
+	return f, conflicts
+}
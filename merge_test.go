@@ -0,0 +1,156 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Merge(t *testing.T) {
+	Convey("Merge three configs", t, func() {
+		base, err := Load([]byte(`// League config
+mp_maxrounds 30
+mp_roundtime 1.92
+mp_freezetime 15
+sv_cheats 0
+tv_enable 1
+`))
+		So(err, ShouldBeNil)
+		// Local tweaks of the server operator
+		ours, err := Load([]byte(`// League config
+mp_maxrounds 30
+mp_roundtime 1.92
+mp_freezetime 10 // shorter
+sv_cheats 0
+tv_enable 1
+hostname "My Server"
+`))
+		So(err, ShouldBeNil)
+		// Next version of the league config
+		theirs, err := Load([]byte(`// League config
+mp_maxrounds 24
+mp_overtime_enable 1
+mp_roundtime 1.92
+mp_freezetime 20
+sv_cheats 0
+`))
+		So(err, ShouldBeNil)
+
+		PrettyFormat = false
+		defer func() { PrettyFormat = true }()
+
+		f, conflicts := Merge(base, ours, theirs)
+		So(conflicts, ShouldHaveLength, 1)
+		So(conflicts[0].Name, ShouldEqual, "mp_freezetime")
+		So(conflicts[0].Base.Value(), ShouldEqual, "15")
+		So(conflicts[0].Ours.Value(), ShouldEqual, "10")
+		So(conflicts[0].Theirs.Value(), ShouldEqual, "20")
+
+		sec := f.Section("")
+		So(sec.Key("mp_maxrounds").Value(), ShouldEqual, "24")
+		So(sec.HasKey("tv_enable"), ShouldBeFalse)
+		So(sec.KeysHash()["mp_overtime_enable"], ShouldEqual, "1")
+
+		var buf bytes.Buffer
+		_, err = f.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, "// League config"+LineBreak+
+			"mp_maxrounds 24"+LineBreak+
+			"mp_overtime_enable 1"+LineBreak+
+			"mp_roundtime 1.92"+LineBreak+
+			"<<<<<<< ours"+LineBreak+
+			"mp_freezetime 10 // shorter"+LineBreak+
+			"======="+LineBreak+
+			"mp_freezetime 20"+LineBreak+
+			">>>>>>> theirs"+LineBreak+
+			"sv_cheats 0"+LineBreak+
+			`hostname "My Server"`+LineBreak)
+
+		Convey("Merge without conflicts", func() {
+			f, conflicts := Merge(base, ours, ours)
+			So(conflicts, ShouldBeEmpty)
+			So(Diff(ours, f), ShouldBeEmpty)
+
+			f, conflicts = Merge(base, base, theirs)
+			So(conflicts, ShouldBeEmpty)
+			So(Diff(theirs, f), ShouldBeEmpty)
+		})
+
+		Convey("Conflict with a removed key", func() {
+			theirs.Section("").DeleteKey("mp_freezetime")
+
+			f, conflicts := Merge(base, ours, theirs)
+			So(conflicts, ShouldHaveLength, 1)
+			So(conflicts[0].Theirs, ShouldBeNil)
+
+			buf.Reset()
+			_, err = f.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "mp_freezetime 10 // shorter"+LineBreak+"======="+LineBreak+">>>>>>> theirs")
+		})
+	})
+
+	Convey("Merge repeated statements", t, func() {
+		base, err := Load([]byte("bind a +jump; bind b +duck\nsay hi\nsay bye\nSv_Cheats 0\n"))
+		So(err, ShouldBeNil)
+		ours, err := Load([]byte("bind a +jump; bind b +duck\nsay hi\nsay bye\nsv_cheats 0\n"))
+		So(err, ShouldBeNil)
+		theirs, err := Load([]byte("bind a +attack; bind b +duck\nsay hello\nsay bye\nbind c +use\nsv_cheats 0\n"))
+		So(err, ShouldBeNil)
+
+		PrettyFormat = false
+		defer func() { PrettyFormat = true }()
+
+		f, conflicts := Merge(base, ours, theirs)
+		So(conflicts, ShouldBeEmpty)
+
+		var buf bytes.Buffer
+		_, err = f.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, "bind a +attack; bind b +duck"+LineBreak+
+			"say hello"+LineBreak+
+			"say bye"+LineBreak+
+			"bind c +use"+LineBreak+
+			"sv_cheats 0"+LineBreak)
+		So(f.Section("").KeysByName("bind"), ShouldHaveLength, 3)
+
+		Convey("Conflict on any line of a repeated key", func() {
+			ours, err := Load([]byte("bind a +jump; bind b +duck\nsay hi\nsay later\nsv_cheats 0\n"))
+			So(err, ShouldBeNil)
+
+			f, conflicts := Merge(base, ours, theirs)
+			So(conflicts, ShouldHaveLength, 1)
+			So(conflicts[0].Name, ShouldEqual, "say")
+			So(conflicts[0].Ours.Value(), ShouldEqual, "later")
+			So(conflicts[0].Theirs.Value(), ShouldEqual, "bye")
+
+			buf.Reset()
+			_, err = f.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "bind a +attack; bind b +duck"+LineBreak+
+				"<<<<<<< ours"+LineBreak+
+				"say hi"+LineBreak+
+				"say later"+LineBreak+
+				"======="+LineBreak+
+				"say hello"+LineBreak+
+				"say bye"+LineBreak+
+				">>>>>>> theirs"+LineBreak+
+				"bind c +use"+LineBreak)
+		})
+	})
+}
@@ -15,12 +15,9 @@
 package csgo_cfg
 
 import (
-	"bufio"
 	"bytes"
-	"fmt"
 	"io"
 	"strings"
-	"unicode"
 )
 
 type tokenType int
@@ -30,49 +27,11 @@ const (
 	_TOKEN_COMMENT
 	_TOKEN_SECTION
 	_TOKEN_KEY
+	_TOKEN_BLANK
+	_TOKEN_CONFLICT
+	_TOKEN_RAW
 )
 
-type parser struct {
-	buf     *bufio.Reader
-	isEOF   bool
-	count   int
-	comment *bytes.Buffer
-}
-
-func newParser(r io.Reader) *parser {
-	return &parser{
-		buf:     bufio.NewReader(r),
-		count:   1,
-		comment: &bytes.Buffer{},
-	}
-}
-
-// BOM handles header of BOM-UTF8 format.
-// http://en.wikipedia.org/wiki/Byte_order_mark#Representations_of_byte_order_marks_by_encoding
-func (p *parser) BOM() error {
-	mask, err := p.buf.Peek(3)
-	if err != nil && err != io.EOF {
-		return err
-	} else if len(mask) < 3 {
-		return nil
-	} else if mask[0] == 239 && mask[1] == 187 && mask[2] == 191 {
-		p.buf.Read(mask)
-	}
-	return nil
-}
-
-func (p *parser) readUntil(delim byte) ([]byte, error) {
-	data, err := p.buf.ReadBytes(delim)
-	if err != nil {
-		if err == io.EOF {
-			p.isEOF = true
-		} else {
-			return nil, err
-		}
-	}
-	return data, nil
-}
-
 func cleanComment(in []byte) ([]byte, bool) {
 	i := bytes.IndexAny(in, "#;")
 	if i == -1 {
@@ -81,112 +40,137 @@ func cleanComment(in []byte) ([]byte, bool) {
 	return in[i:], true
 }
 
-func readKeyName(in []byte) (string, int, error) {
-	line := string(in)
-
-	// Get out key name
-	endIdx := strings.Index(line, " ")
-	if endIdx < 0 {
-		return "", -1, ErrDelimiterNotFound{line}
+// parseError returns a ParseError of given kind at given line and column of source.
+// With LoadOptions.CollectErrors, it is recorded and nil is returned instead.
+func (f *File) parseError(source Location, line, column int, kind ParseErrorKind, err error) error {
+	perr := &ParseError{Location: source, Kind: kind, Err: err}
+	perr.Line = line
+	perr.Column = column
+	if f.options.CollectErrors {
+		f.parseErrors = append(f.parseErrors, perr)
+		return nil
 	}
-	return strings.TrimSpace(line[0:endIdx]), endIdx + 1, nil
+	return perr
 }
 
-// hasSurroundedQuote check if and only if the first and last characters
-// are quotes \" or \'.
-// It returns false if any other parts also contain same kind of quotes.
-func hasSurroundedQuote(in string, quote byte) bool {
-	return len(in) > 2 && in[0] == quote && in[len(in)-1] == quote &&
-		strings.IndexByte(in[1:], quote) == len(in)-2
-}
-
-// value, isString, comment, error
-func (p *parser) readValue(in []byte) (string, bool, string, error) {
-	line := strings.TrimLeftFunc(string(in), unicode.IsSpace)
-
-	// Line empty, or nothing before the comment
-	if len(line) == 0 || (len(line) > 1 && line[0:1] == "//") {
-		return "", true, "", nil
-	}
+// parse parses data through an io.Reader,
+// recording given source location in every key.
+func (f *File) parse(reader io.Reader, source Location) error {
+	sc := NewScanner(reader)
+	section, _ := f.NewSection(DEFAULT_SECTION)
 
-	// Drop everything after space, unless space is within a string
-	var valueEndIndex int
-	insideQuote := false
-	for _, c := range line {
-		if c == ' ' && !insideQuote {
-			break
+	// Statement being read, and the node of the last one on the current line.
+	var (
+		stmt    *Token
+		args    []Arg
+		space   string
+		keyNode *node
+		content bool
+	)
+	// Current line, the first node read from it, and end of the last token.
+	var (
+		line      *rawLine
+		lineStart int
+		prevEnd   int
+	)
+
+	flush := func() {
+		if stmt == nil {
+			return
 		}
-		if c == '"' {
-			insideQuote = !insideQuote
+		kname := stmt.Value
+		if f.options.Insensitive {
+			kname = strings.ToLower(kname)
 		}
-		valueEndIndex++
-	}
-
-	// Ended the string without matching quote. Invalid
-	if insideQuote {
-		return "", false, "", ErrDelimiterNotFound{line}
-	}
-
-	// Remove
-	valueLine := strings.TrimSpace(line[:valueEndIndex])
-    comment := ""
-    if len(in) > valueEndIndex {
-        comment = line[valueEndIndex:]
-    }    
-
-	if hasSurroundedQuote(valueLine, '\'') ||
-		hasSurroundedQuote(valueLine, '"') {
-		return valueLine[1 : len(valueLine)-1], true, comment, nil
-	}
-
-	// Should be a number at this point
-	return valueLine, false, comment, nil
-}
-
-// parse parses data through an io.Reader.
-func (f *File) parse(reader io.Reader) (err error) {
-	p := newParser(reader)
-	if err = p.BOM(); err != nil {
-		return fmt.Errorf("BOM: %v", err)
-	}
-
-	section, _ := f.NewSection(DEFAULT_SECTION)
-
-	var line []byte
-	for !p.isEOF {
-		line, err = p.readUntil('\n')
-		if err != nil {
-			return err
+		if f.BlockMode {
+			f.lock.Lock()
 		}
-
-		line = bytes.TrimLeftFunc(line, unicode.IsSpace)
-		if len(line) == 0 {
-			continue
+		key := section.appendKey(kname, args, keyNode != nil)
+		key.loc = source
+		key.loc.Line = stmt.Pos.Line
+		key.loc.Column = stmt.Pos.Column
+		keyNode = section.nodes[len(section.nodes)-1]
+		keyNode.raw = &rawKey{args: copyArgs(args), space: space}
+		if f.BlockMode {
+			f.lock.Unlock()
 		}
-
-		kname, offset, err := readKeyName(line)
-		if err != nil {
-			return err
+		stmt, args, space = nil, nil, ""
+	}
+	endLine := func(newline string) {
+		line.newline = newline
+		if len(section.nodes) == lineStart {
+			section.nodes = append(section.nodes, &node{typ: _TOKEN_RAW})
 		}
-
-		key, err := section.NewKey(kname, "")
-		if err != nil {
-			return err
+		for _, n := range section.nodes[lineStart:] {
+			n.line = line
 		}
+		line.nodes = len(section.nodes) - lineStart
+		line = nil
+	}
 
-		value, isString, comment, err := p.readValue(line[offset:])
-		if err != nil {
-			return err
+	for sc.Scan() {
+		tok := sc.Token()
+		if line == nil {
+			line = &rawLine{text: sc.text}
+			lineStart = len(section.nodes)
+			prevEnd = 0
 		}
-
-		key.SetValue(value)
-		key.isString = isString
-
-		// Comments
-        comment = strings.TrimLeftFunc(comment, unicode.IsSpace)
-		if len(comment) > 1 && comment[0:2] == "//" {
-			key.Comment = strings.TrimSpace(comment)
+		gap := line.text[prevEnd : tok.Pos.Column-1]
+		prevEnd = tok.Pos.Column - 1 + len(tok.Text)
+
+		switch tok.Kind {
+		case TokenCommand:
+			flush()
+			stmt = &tok
+		case TokenArg, TokenQuotedArg:
+			if len(args) == 0 {
+				space = gap
+			}
+			args = append(args, Arg{Value: tok.Value, Quoted: tok.Kind == TokenQuotedArg})
+		case TokenSeparator:
+			flush()
+		case TokenComment:
+			flush()
+			if keyNode != nil {
+				keyNode.key.Comment = tok.Value
+				keyNode.raw.comment = tok.Value
+				keyNode.raw.commentSpace = gap
+			} else {
+				section.NewComment(tok.Value)
+			}
+		case TokenNewline:
+			flush()
+			if !content {
+				section.NewBlankLine()
+			}
+			endLine(tok.Text)
+			keyNode, content = nil, false
+			continue
+		case TokenInvalid:
+			// Drop the statement, and skip the rest of the line when collecting errors.
+			stmt, args = nil, nil
+			kind := ErrKindDelimiterNotFound
+			if IsErrInvalidKeyName(tok.Err) {
+				kind = ErrKindInvalidKeyName
+			}
+			if err := f.parseError(source, tok.Pos.Line, tok.Pos.Column, kind, tok.Err); err != nil {
+				return err
+			}
 		}
+		content = true
+	}
+	flush()
+
+	// Last line without line break, which may hold nothing but whitespace.
+	if line == nil && len(sc.text) > 0 {
+		line = &rawLine{text: sc.text}
+		lineStart = len(section.nodes)
+	}
+	if line != nil {
+		endLine("")
+	}
+	if source.Index == 0 {
+		f.bom = sc.bom
 	}
-	return nil
+	return sc.Err()
 }
@@ -0,0 +1,86 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+// _BOM is the UTF-8 byte order mark.
+const _BOM = "\xef\xbb\xbf"
+
+// rawLine is a line of a data source as it was read. With PreserveFormat,
+// it is written back as is while none of its nodes change.
+type rawLine struct {
+	// text is the line without its line break.
+	text    string
+	newline string
+	// nodes is number of nodes read from the line.
+	nodes int
+}
+
+// rawKey is how a key was written when read, to tell whether it changed
+// and to keep its spacing when it did.
+type rawKey struct {
+	args    []Arg
+	comment string
+	// Whitespace between name and first argument, and before comment.
+	space        string
+	commentSpace string
+}
+
+// changed returns true if key of n differs from what was read.
+func (n *node) changed() bool {
+	if n.typ != _TOKEN_KEY {
+		return false
+	}
+	if n.raw == nil || n.key.Comment != n.raw.comment || len(n.key.args) != len(n.raw.args) {
+		return true
+	}
+	for i := range n.key.args {
+		if n.key.args[i] != n.raw.args[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// unchangedLine returns number of nodes of the line that nodes start with,
+// if all of them are still in place and none changed since it was read,
+// or zero otherwise.
+func unchangedLine(nodes []*node) int {
+	line := nodes[0].line
+	if line == nil || len(nodes) < line.nodes {
+		return 0
+	}
+	if len(nodes) > line.nodes && nodes[line.nodes].line == line {
+		return 0
+	}
+	for _, n := range nodes[:line.nodes] {
+		if n.line != line || n.changed() {
+			return 0
+		}
+	}
+	return line.nodes
+}
+
+// lineBreak returns line break of the first line read that has one,
+// or LineBreak if there is none.
+func (f *File) lineBreak() string {
+	for _, sname := range f.sectionList {
+		for _, n := range f.sections[sname].nodes {
+			if n.line != nil && len(n.line.newline) > 0 {
+				return n.line.newline
+			}
+		}
+	}
+	return LineBreak
+}
@@ -0,0 +1,85 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_PreserveFormat(t *testing.T) {
+	Convey("Write configs back byte for byte", t, func() {
+		PreserveFormat = true
+		defer func() { PreserveFormat = false }()
+
+		data := "\xef\xbb\xbf// Match settings\r\n" +
+			"hostname\t\"Test Server\"   // name\r\n" +
+			"mp_freezetime 15;mp_roundtime   1.92 ;  mp_restartgame 1\r\n" +
+			"  \r\n" +
+			";\r\n" +
+			"mp_maxrounds      30  \r\n" +
+			"bot_kick"
+		cfg, err := Load([]byte(data))
+		So(err, ShouldBeNil)
+
+		var buf bytes.Buffer
+		_, err = cfg.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+
+		conf, err := os.ReadFile("testdata/conf.cfg")
+		So(err, ShouldBeNil)
+		cfg2, err := Load(conf)
+		So(err, ShouldBeNil)
+		buf.Reset()
+		_, err = cfg2.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, string(conf))
+
+		Convey("Only write changed statements again", func() {
+			sec := cfg.Section("")
+			sec.Key("mp_maxrounds").SetValue("24")
+			sec.Key("hostname").SetValue("Match Server")
+			sec.Key("mp_roundtime").Comment = "// MR12"
+			_, err := sec.NewKey("sv_cheats", "0")
+			So(err, ShouldBeNil)
+
+			buf.Reset()
+			_, err = cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, "\xef\xbb\xbf// Match settings\r\n"+
+				"hostname\t\"Match Server\"   // name\r\n"+
+				"mp_freezetime 15; mp_roundtime   1.92 // MR12\r\n"+
+				"mp_restartgame 1\r\n"+
+				"  \r\n"+
+				";\r\n"+
+				"mp_maxrounds      24\r\n"+
+				"bot_kick\r\n"+
+				"sv_cheats 0\r\n")
+		})
+
+		Convey("Drop lines of deleted keys", func() {
+			cfg.Section("").DeleteKey("mp_freezetime")
+
+			buf.Reset()
+			_, err := cfg.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "\r\nmp_roundtime   1.92; mp_restartgame 1\r\n  \r\n")
+		})
+	})
+}
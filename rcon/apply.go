@@ -0,0 +1,123 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rcon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// Beginnings of console output that tell a command failed.
+var failurePrefixes = []string{
+	"Unknown command",
+	"Can't change",
+	"Can't use cheat command",
+	"Can't set",
+	"Command is disabled",
+}
+
+type ErrCommandFailed struct {
+	Command  string
+	Response string
+}
+
+func IsErrCommandFailed(err error) bool {
+	return errors.As(err, new(ErrCommandFailed))
+}
+
+func (err ErrCommandFailed) Error() string {
+	return fmt.Sprintf("command '%s' failed: %s", err.Command, strings.TrimSpace(err.Response))
+}
+
+// Result represents outcome of sending a statement to the server.
+type Result struct {
+	Key      *cfg.Key
+	Command  string
+	Response string
+	// Err is ErrCommandFailed if the server rejected the command.
+	Err error
+}
+
+// isFailure returns true if console output tells the command failed.
+func isFailure(resp string) bool {
+	for _, line := range strings.Split(resp, "\n") {
+		line = strings.TrimSpace(line)
+		for _, prefix := range failurePrefixes {
+			if strings.HasPrefix(line, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply runs every statement of the config on the server in order, one
+// command at a time, and returns results of them. Statements of files
+// loaded by "exec" are sent in place of the "exec" statement when they
+// are resolved, so the server does not need the files. Commands rejected
+// by the server are reported by Result.Err, while connection errors stop
+// it and are returned along with results so far.
+func Apply(ctx context.Context, conn *Conn, f *cfg.File) ([]*Result, error) {
+	execs := make(map[*cfg.Key]bool)
+	var collect func(f *cfg.File)
+	collect = func(f *cfg.File) {
+		for _, inc := range f.Includes() {
+			execs[inc.Key] = true
+			collect(inc.File)
+		}
+	}
+	collect(f)
+
+	var results []*Result
+	for _, k := range f.Statements() {
+		if execs[k] {
+			continue
+		}
+
+		// A quote or line break would end the statement early and run
+		// whatever follows as another command.
+		if err := k.CheckValue(); err != nil {
+			return results, fmt.Errorf("error applying statement at %s: %w", k.Location(), err)
+		}
+
+		cmd := k.Statement()
+		resp, err := conn.Exec(ctx, cmd)
+		if err != nil {
+			return results, fmt.Errorf("error applying '%s' at %s: %v", cmd, k.Location(), err)
+		}
+
+		r := &Result{Key: k, Command: cmd, Response: resp}
+		if isFailure(resp) {
+			r.Err = ErrCommandFailed{cmd, resp}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// Failed returns results of commands the server rejected.
+func Failed(results []*Result) []*Result {
+	var failed []*Result
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,133 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rcon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// Console output of a cvar: "mp_maxrounds" = "30" ( def. "0" ) min. 0.000000 ...
+var cvarPattern = regexp.MustCompile(`(?m)^\s*"([^"]+)"\s*=\s*"([^"]*)"`)
+
+// ParseCvarResponse returns name and value of a cvar in console output
+// of querying it, e.g. `"mp_maxrounds" = "30" ( def. "0" )`.
+func ParseCvarResponse(resp string) (name, value string, ok bool) {
+	m := cvarPattern.FindStringSubmatch(resp)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// DriftKind represents how a cvar on the server differs from the config.
+type DriftKind string
+
+const (
+	// DriftChanged means the cvar has another value on the server.
+	DriftChanged DriftKind = "changed"
+	// DriftUnknown means the server does not know the cvar.
+	DriftUnknown DriftKind = "unknown"
+	// DriftNotQueried means the name is not a cvar known to the schema,
+	// so it is not sent to the server, where it could run a command.
+	DriftNotQueried DriftKind = "not-queried"
+)
+
+// Mismatch represents a cvar whose value on the server is not the one
+// the config sets.
+type Mismatch struct {
+	Kind DriftKind
+	// Key is the statement that sets the expected value.
+	Key      *cfg.Key
+	Expected string
+	// Actual is the value on the server, empty if it is unknown.
+	Actual string
+}
+
+func (m *Mismatch) String() string {
+	switch m.Kind {
+	case DriftUnknown:
+		return fmt.Sprintf("%s: %s: unknown cvar on server", m.Key.Location(), m.Key.Name())
+	case DriftNotQueried:
+		return fmt.Sprintf("%s: %s: not queried, not a known cvar", m.Key.Location(), m.Key.Name())
+	}
+	return fmt.Sprintf("%s: %s: expected '%s', server has '%s'", m.Key.Location(), m.Key.Name(), m.Expected, m.Actual)
+}
+
+// sameValue returns true if values are equal, or are the same number
+// as the console may print numbers differently, e.g. "1.0" and "1".
+func sameValue(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	return errX == nil && errY == nil && x == y
+}
+
+// Drift queries the current value of every cvar the config sets, and
+// returns the ones that differ on the server. Effective values are
+// checked, following "exec" statements when they are resolved. Only cvars
+// known to the bundled schema are queried, since querying runs a command of
+// the same name; other names are returned as DriftNotQueried. Commands and
+// keys without value are skipped.
+func Drift(ctx context.Context, conn *Conn, f *cfg.File) ([]*Mismatch, error) {
+	return DriftWithSchema(ctx, conn, f, cfg.DefaultSchema())
+}
+
+// DriftWithSchema is like Drift, but queries cvars known to given schema,
+// e.g. one parsed from "cvarlist" output of the server.
+func DriftWithSchema(ctx context.Context, conn *Conn, f *cfg.File, schema *cfg.Schema) ([]*Mismatch, error) {
+	var names []string
+	keys := make(map[string]*cfg.Key)
+	for _, k := range f.Statements() {
+		if cv := schema.Convar(k.Name()); !k.HasValue() || (cv != nil && cv.Type == cfg.ConvarCommand) {
+			continue
+		}
+		if _, ok := keys[k.Name()]; !ok {
+			names = append(names, k.Name())
+		}
+		keys[k.Name()] = k
+	}
+
+	var mismatches []*Mismatch
+	for _, name := range names {
+		k := keys[name]
+		if schema.Convar(name) == nil {
+			mismatches = append(mismatches, &Mismatch{Kind: DriftNotQueried, Key: k, Expected: k.Value()})
+			continue
+		}
+
+		resp, err := conn.Exec(ctx, name)
+		if err != nil {
+			return mismatches, fmt.Errorf("error querying '%s': %v", name, err)
+		}
+
+		rname, val, ok := ParseCvarResponse(resp)
+		if !ok || !strings.EqualFold(rname, name) {
+			mismatches = append(mismatches, &Mismatch{Kind: DriftUnknown, Key: k, Expected: k.Value()})
+			continue
+		}
+		if !sameValue(k.Value(), val) {
+			mismatches = append(mismatches, &Mismatch{Kind: DriftChanged, Key: k, Expected: k.Value(), Actual: val})
+		}
+	}
+	return mismatches, nil
+}
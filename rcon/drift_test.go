@@ -0,0 +1,104 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rcon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+func Test_ParseCvarResponse(t *testing.T) {
+	Convey("Parse console output of a cvar", t, func() {
+		name, val, ok := ParseCvarResponse(`"mp_maxrounds" = "30" ( def. "0" ) min. 0.000000 game notify replicated release
+ - max number of rounds to play before server changes maps`)
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "mp_maxrounds")
+		So(val, ShouldEqual, "30")
+
+		_, val, ok = ParseCvarResponse(`"hostname" = ""`)
+		So(ok, ShouldBeTrue)
+		So(val, ShouldBeEmpty)
+
+		_, _, ok = ParseCvarResponse(`Unknown command "mp_nonexistent"`)
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func Test_Drift(t *testing.T) {
+	Convey("Find cvars that differ on the server", t, func() {
+		s := newFakeServer(t, "secret")
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		c, err := Dial(ctx, s.Addr(), "secret")
+		So(err, ShouldBeNil)
+		defer c.Close()
+
+		f, err := cfg.Load([]byte(`mp_maxrounds 24
+mp_maxrounds 30.0
+mp_freezetime 10
+sv_cheats 0
+bot_kick
+log on
+mp_nonexistent 1`))
+		So(err, ShouldBeNil)
+
+		mismatches, err := Drift(ctx, c, f)
+		So(err, ShouldBeNil)
+		So(mismatches, ShouldHaveLength, 3)
+		So(mismatches[0].Kind, ShouldEqual, DriftChanged)
+		So(mismatches[0].Key.Name(), ShouldEqual, "mp_freezetime")
+		So(mismatches[0].Actual, ShouldEqual, "15")
+		So(mismatches[0].String(), ShouldEqual, "<source 0>:3: mp_freezetime: expected '10', server has '15'")
+		So(mismatches[1].Kind, ShouldEqual, DriftNotQueried)
+		So(mismatches[1].String(), ShouldEqual, "<source 0>:6: log: not queried, not a known cvar")
+		So(mismatches[2].Kind, ShouldEqual, DriftNotQueried)
+		So(mismatches[2].Key.Name(), ShouldEqual, "mp_nonexistent")
+
+		// Names that are not known cvars are never sent.
+		So(s.commands, ShouldResemble, []string{"mp_maxrounds", "mp_freezetime", "sv_cheats"})
+
+		Convey("Query cvars of given schema", func() {
+			schema := cfg.NewSchema(
+				&cfg.Convar{Name: "mp_freezetime", Type: cfg.ConvarInt},
+				&cfg.Convar{Name: "mp_nonexistent", Type: cfg.ConvarInt},
+			)
+			mismatches, err := DriftWithSchema(ctx, c, f, schema)
+			So(err, ShouldBeNil)
+			So(mismatches, ShouldHaveLength, 5)
+			So(mismatches[0].Kind, ShouldEqual, DriftNotQueried)
+			So(mismatches[1].Key.Name(), ShouldEqual, "mp_freezetime")
+			So(mismatches[4].Kind, ShouldEqual, DriftUnknown)
+			So(mismatches[4].Key.Name(), ShouldEqual, "mp_nonexistent")
+		})
+
+		Convey("No drift after applying the config", func() {
+			_, err := Apply(ctx, c, f)
+			So(err, ShouldBeNil)
+
+			mismatches, err := Drift(ctx, c, f)
+			So(err, ShouldBeNil)
+			So(mismatches, ShouldHaveLength, 2)
+			So(mismatches[0].Kind, ShouldEqual, DriftNotQueried)
+		})
+	})
+}
@@ -0,0 +1,240 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rcon implements a client of the Source RCON protocol, to run
+// commands on a live CS:GO server and push configs to it.
+//
+// https://developer.valvesoftware.com/wiki/Source_RCON_Protocol
+package rcon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Packet types
+const (
+	_SERVERDATA_RESPONSE_VALUE = 0
+	_SERVERDATA_EXECCOMMAND    = 2
+	_SERVERDATA_AUTH_RESPONSE  = 2
+	_SERVERDATA_AUTH           = 3
+)
+
+const (
+	// Size of ID and type fields, and the two terminating zero bytes.
+	_PACKET_HEADER_SIZE = 4 + 4 + 2
+	// Maximum size of a packet, not counting the size field.
+	_PACKET_MAX_SIZE = 4096
+)
+
+type ErrAuthFailed struct{}
+
+func IsErrAuthFailed(err error) bool {
+	return errors.As(err, new(ErrAuthFailed))
+}
+
+func (err ErrAuthFailed) Error() string {
+	return "rcon authentication failed: bad password"
+}
+
+type packet struct {
+	id   int32
+	typ  int32
+	body string
+}
+
+func writePacket(w io.Writer, p packet) error {
+	size := int32(_PACKET_HEADER_SIZE + len(p.body))
+	if size > _PACKET_MAX_SIZE {
+		return fmt.Errorf("rcon packet too large: %d bytes", size)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, size+4))
+	binary.Write(buf, binary.LittleEndian, size)
+	binary.Write(buf, binary.LittleEndian, p.id)
+	binary.Write(buf, binary.LittleEndian, p.typ)
+	buf.WriteString(p.body)
+	buf.Write([]byte{0, 0})
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func readPacket(r io.Reader) (packet, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return packet{}, err
+	}
+	if size < _PACKET_HEADER_SIZE || size > _PACKET_MAX_SIZE {
+		return packet{}, fmt.Errorf("bad rcon packet size: %d", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return packet{}, err
+	}
+	return packet{
+		id:   int32(binary.LittleEndian.Uint32(data[0:4])),
+		typ:  int32(binary.LittleEndian.Uint32(data[4:8])),
+		body: string(bytes.TrimRight(data[8:], "\x00")),
+	}, nil
+}
+
+// Conn represents an authenticated RCON connection. It is safe to use
+// by multiple goroutines, commands are run one at a time.
+type Conn struct {
+	lock   sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	lastID int32
+}
+
+// Dial connects to RCON server at given address, e.g. "127.0.0.1:27015",
+// and authenticates with password.
+func Dial(ctx context.Context, addr, password string) (*Conn, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := NewConn(ctx, nc, password)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewConn authenticates over an established connection.
+func NewConn(ctx context.Context, nc net.Conn, password string) (*Conn, error) {
+	c := &Conn{
+		conn:   nc,
+		reader: bufio.NewReader(nc),
+	}
+
+	err := c.withContext(ctx, func() error {
+		id := c.nextID()
+		if err := writePacket(c.conn, packet{id, _SERVERDATA_AUTH, password}); err != nil {
+			return err
+		}
+		for {
+			p, err := readPacket(c.reader)
+			if err != nil {
+				return err
+			}
+			// Server sends an empty response value before the result.
+			if p.typ != _SERVERDATA_AUTH_RESPONSE {
+				continue
+			}
+			if p.id == -1 {
+				return ErrAuthFailed{}
+			} else if p.id != id {
+				return fmt.Errorf("unexpected rcon auth response id: %d", p.id)
+			}
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Conn) nextID() int32 {
+	c.lastID++
+	if c.lastID <= 0 {
+		c.lastID = 1
+	}
+	return c.lastID
+}
+
+// withContext runs fn with connection deadline set by ctx,
+// and interrupts it when ctx is done.
+func (c *Conn) withContext(ctx context.Context, fn func() error) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+	} else {
+		c.conn.SetDeadline(time.Time{})
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.conn.SetDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	err := fn()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// Exec runs command on the server and returns its output. Output longer
+// than a single packet is put together from all packets that carry it.
+// If ctx is done before the output is read, the connection may be left
+// in the middle of a packet and should be closed.
+func (c *Conn) Exec(ctx context.Context, cmd string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var out bytes.Buffer
+	err := c.withContext(ctx, func() error {
+		id, endID := c.nextID(), c.nextID()
+		if err := writePacket(c.conn, packet{id, _SERVERDATA_EXECCOMMAND, cmd}); err != nil {
+			return err
+		}
+		// The server answers packets in order, so the response to an empty
+		// packet marks the end of output of the command.
+		if err := writePacket(c.conn, packet{endID, _SERVERDATA_RESPONSE_VALUE, ""}); err != nil {
+			return err
+		}
+
+		for {
+			p, err := readPacket(c.reader)
+			if err != nil {
+				return err
+			}
+			switch p.id {
+			case id:
+				out.WriteString(p.body)
+			case endID:
+				return nil
+			}
+			// Anything else is left from earlier commands, such as the extra
+			// packet servers send after mirroring the empty one.
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,240 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rcon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// fakeServer is a minimal RCON server that behaves like a CS:GO console.
+type fakeServer struct {
+	listener net.Listener
+	password string
+
+	lock     sync.Mutex
+	cvars    map[string]string
+	cheats   map[string]bool
+	commands []string
+}
+
+func newFakeServer(t *testing.T, password string) *fakeServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeServer{
+		listener: l,
+		password: password,
+		cvars: map[string]string{
+			"hostname":         "Counter-Strike: Global Offensive",
+			"mp_maxrounds":     "30",
+			"mp_freezetime":    "15",
+			"sv_cheats":        "0",
+			"sv_infinite_ammo": "0",
+		},
+		cheats: map[string]bool{"sv_infinite_ammo": true},
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeServer) Close() {
+	s.listener.Close()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(c)
+	}
+}
+
+func (s *fakeServer) handle(c net.Conn) {
+	defer c.Close()
+	for {
+		p, err := readPacket(c)
+		if err != nil {
+			return
+		}
+
+		switch p.typ {
+		case _SERVERDATA_AUTH:
+			writePacket(c, packet{p.id, _SERVERDATA_RESPONSE_VALUE, ""})
+			id := p.id
+			if p.body != s.password {
+				id = -1
+			}
+			writePacket(c, packet{id, _SERVERDATA_AUTH_RESPONSE, ""})
+		case _SERVERDATA_EXECCOMMAND:
+			resp := s.exec(p.body)
+			// Long output is split into several packets.
+			for len(resp) > 0 {
+				n := len(resp)
+				if n > 1000 {
+					n = 1000
+				}
+				writePacket(c, packet{p.id, _SERVERDATA_RESPONSE_VALUE, resp[:n]})
+				resp = resp[n:]
+			}
+		case _SERVERDATA_RESPONSE_VALUE:
+			writePacket(c, packet{p.id, _SERVERDATA_RESPONSE_VALUE, ""})
+			writePacket(c, packet{p.id, _SERVERDATA_RESPONSE_VALUE, "\x00\x00\x00\x01"})
+		}
+	}
+}
+
+func (s *fakeServer) exec(cmd string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.commands = append(s.commands, cmd)
+
+	fields := strings.SplitN(cmd, " ", 2)
+	name := fields[0]
+	switch name {
+	case "status":
+		return strings.Repeat("# userid name uniqueid connected ping loss state rate\n", 100)
+	case "execifexists":
+		return ""
+	}
+
+	val, ok := s.cvars[name]
+	switch {
+	case !ok:
+		return fmt.Sprintf("Unknown command \"%s\"\n", name)
+	case len(fields) == 1:
+		return fmt.Sprintf("\"%s\" = \"%s\"\n", name, val)
+	case s.cheats[name] && s.cvars["sv_cheats"] == "0":
+		return fmt.Sprintf("Can't change cheat cvar '%s' in multiplayer, unless the server has sv_cheats set to 1.\n", name)
+	}
+	s.cvars[name] = strings.Trim(fields[1], `"`)
+	return ""
+}
+
+func Test_Conn(t *testing.T) {
+	Convey("Run commands over RCON", t, func() {
+		s := newFakeServer(t, "secret")
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		Convey("Authenticate", func() {
+			_, err := Dial(ctx, s.Addr(), "wrong")
+			So(IsErrAuthFailed(err), ShouldBeTrue)
+
+			c, err := Dial(ctx, s.Addr(), "secret")
+			So(err, ShouldBeNil)
+			So(c.Close(), ShouldBeNil)
+		})
+
+		Convey("Exec commands", func() {
+			c, err := Dial(ctx, s.Addr(), "secret")
+			So(err, ShouldBeNil)
+			defer c.Close()
+
+			resp, err := c.Exec(ctx, "mp_maxrounds")
+			So(err, ShouldBeNil)
+			So(resp, ShouldEqual, "\"mp_maxrounds\" = \"30\"\n")
+
+			resp, err = c.Exec(ctx, "status")
+			So(err, ShouldBeNil)
+			So(len(resp), ShouldBeGreaterThan, _PACKET_MAX_SIZE)
+			So(strings.Count(resp, "\n"), ShouldEqual, 100)
+
+			resp, err = c.Exec(ctx, "mp_freezetime 10")
+			So(err, ShouldBeNil)
+			So(resp, ShouldBeEmpty)
+			resp, err = c.Exec(ctx, "mp_freezetime")
+			So(err, ShouldBeNil)
+			So(resp, ShouldEqual, "\"mp_freezetime\" = \"10\"\n")
+		})
+
+		Convey("Cancel a command", func() {
+			c, err := Dial(ctx, s.Addr(), "secret")
+			So(err, ShouldBeNil)
+			defer c.Close()
+
+			cctx, ccancel := context.WithCancel(ctx)
+			ccancel()
+			_, err = c.Exec(cctx, "status")
+			So(err, ShouldEqual, context.Canceled)
+		})
+	})
+}
+
+func Test_Apply(t *testing.T) {
+	Convey("Apply a config to a server", t, func() {
+		s := newFakeServer(t, "secret")
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		c, err := Dial(ctx, s.Addr(), "secret")
+		So(err, ShouldBeNil)
+		defer c.Close()
+
+		f, err := cfg.LoadSources(cfg.LoadOptions{ResolveExec: true}, "../testdata/exec/server.cfg", []byte(`sv_infinite_ammo 1
+mp_nonexistent 1`))
+		So(err, ShouldBeNil)
+
+		results, err := Apply(ctx, c, f)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 9)
+		So(results[0].Command, ShouldEqual, `hostname "Test Server"`)
+		So(results[0].Err, ShouldBeNil)
+
+		Convey("Refuse values that would inject commands", func() {
+			f.Section("").Key("hostname").SetValue(`x"; rcon_password "`)
+			results, err := Apply(ctx, c, f)
+			So(cfg.IsErrUnrepresentableValue(err), ShouldBeTrue)
+			So(results, ShouldBeEmpty)
+		})
+
+		failed := Failed(results)
+		So(failed, ShouldHaveLength, 3)
+		So(failed[0].Command, ShouldEqual, "mp_overtime_enable 1")
+		So(failed[1].Key.Name(), ShouldEqual, "sv_infinite_ammo")
+		So(IsErrCommandFailed(failed[1].Err), ShouldBeTrue)
+		So(failed[2].Err.Error(), ShouldEqual, `command 'mp_nonexistent 1' failed: Unknown command "mp_nonexistent"`)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		So(s.cvars["hostname"], ShouldEqual, "Test Server")
+		So(s.cvars["mp_maxrounds"], ShouldEqual, "30")
+		// Files of exec statements are sent instead.
+		for _, cmd := range s.commands {
+			So(cmd, ShouldNotStartWith, "exec ")
+		}
+	})
+}
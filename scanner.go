@@ -0,0 +1,299 @@
+// Copyright 2015 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// TokenKind represents kind of a token read by Scanner.
+type TokenKind int
+
+const (
+	// TokenInvalid is the rest of a line that cannot be read,
+	// the reason of which is given by Token.Err.
+	TokenInvalid TokenKind = iota
+	// TokenCommand is name of a command or cvar, e.g. "mp_maxrounds".
+	TokenCommand
+	// TokenArg is an argument not wrapped in "", e.g. "30".
+	TokenArg
+	// TokenQuotedArg is an argument wrapped in "", e.g. "Test Server".
+	TokenQuotedArg
+	// TokenSeparator is a ";" between statements on the same line.
+	TokenSeparator
+	// TokenComment is a comment from "//" until the end of the line.
+	TokenComment
+	// TokenNewline is a line break, "\n" or "\r\n".
+	TokenNewline
+)
+
+var tokenKindNames = []string{"invalid", "command", "arg", "quoted-arg", "separator", "comment", "newline"}
+
+func (k TokenKind) String() string {
+	if k < 0 || int(k) >= len(tokenKindNames) {
+		return fmt.Sprintf("TokenKind(%d)", int(k))
+	}
+	return tokenKindNames[k]
+}
+
+// Position represents where a token starts in the data.
+type Position struct {
+	// Offset is number of bytes before the token, starting from 0.
+	Offset int
+	// Line and Column start from 1. Column counts bytes,
+	// and does not count a leading BOM.
+	Line   int
+	Column int
+}
+
+// Token represents a single token of a config.
+type Token struct {
+	Kind TokenKind
+	Pos  Position
+	// Text is the token as it is in the data, e.g. with quotes.
+	Text string
+	// Value is argument without quotes, and the same as Text otherwise.
+	Value string
+	// Err is why a TokenInvalid cannot be read.
+	Err error
+}
+
+// isKeyNameRune reports whether r may appear in a command or cvar name,
+// e.g. "mp_roundtime", "+attack" or "cl_radar_scale.1".
+func isKeyNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) ||
+		r == '_' || r == '+' || r == '-' || r == '.'
+}
+
+// Scanner reads tokens of a config from an io.Reader one at a time,
+// following the same grammar as Load. Whitespace other than line breaks
+// is skipped, and can be told from positions of tokens.
+//
+// As in the console, arguments are separated by whitespace unless wrapped
+// in "", an unquoted ";" ends the statement and everything after an
+// unquoted "//" is comment. "//" and ";" are kept inside quotes, a quote
+// starts a new argument even within a word, and there are no escape
+// sequences, so a backslash is kept and the next quote always ends the
+// argument.
+type Scanner struct {
+	r   *bufio.Reader
+	err error
+	eof bool
+
+	// Tokens of the current line not returned yet.
+	tokens []Token
+	tok    Token
+
+	line   int
+	offset int
+	// Whether the start of data was checked for BOM, and found it.
+	bomChecked bool
+	bom        bool
+	// Current line without its line break.
+	text string
+}
+
+// NewScanner returns a new Scanner to read from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{r: bufio.NewReader(r)}
+}
+
+// Scan advances to the next token, which is then available through Token.
+// It returns false at the end of data or on a read error, which is then
+// returned by Err. Lines that cannot be read do not stop scanning but
+// are returned as TokenInvalid.
+func (s *Scanner) Scan() bool {
+	for len(s.tokens) == 0 {
+		if s.eof || s.err != nil {
+			return false
+		}
+		s.readLine()
+	}
+	s.tok = s.tokens[0]
+	s.tokens = s.tokens[1:]
+	return true
+}
+
+// Token returns the token read by the last call to Scan.
+func (s *Scanner) Token() Token {
+	return s.tok
+}
+
+// Err returns the first read error, or nil at the end of data.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// readLine reads the next line and splits it into tokens.
+func (s *Scanner) readLine() {
+	data, err := s.r.ReadString('\n')
+	if err != nil {
+		if err != io.EOF {
+			s.err = err
+			return
+		}
+		s.eof = true
+	}
+
+	// BOM handles header of BOM-UTF8 format.
+	// http://en.wikipedia.org/wiki/Byte_order_mark#Representations_of_byte_order_marks_by_encoding
+	start := s.offset
+	if !s.bomChecked {
+		s.bomChecked = true
+		if strings.HasPrefix(data, "\xef\xbb\xbf") {
+			s.bom = true
+			data = data[3:]
+			start += 3
+		}
+	}
+	s.line++
+	s.offset = start + len(data)
+	s.text = ""
+
+	if len(data) == 0 {
+		return
+	}
+
+	line, newline := data, ""
+	if strings.HasSuffix(line, "\n") {
+		line = line[:len(line)-1]
+		newline = "\n"
+		if strings.HasSuffix(line, "\r") {
+			line = line[:len(line)-1]
+			newline = "\r\n"
+		}
+	}
+
+	s.text = line
+	s.tokens = splitLine(s.tokens[:0], line, s.line, start)
+	if len(newline) > 0 {
+		s.tokens = append(s.tokens, Token{
+			Kind:  TokenNewline,
+			Pos:   Position{Offset: start + len(line), Line: s.line, Column: len(line) + 1},
+			Text:  newline,
+			Value: newline,
+		})
+	}
+}
+
+// splitLine appends tokens of a line without its line break to tokens.
+func splitLine(tokens []Token, line string, lineNum, offset int) []Token {
+	i := 0
+	add := func(kind TokenKind, end int, value string, err error) {
+		tokens = append(tokens, Token{
+			Kind:  kind,
+			Pos:   Position{Offset: offset + i, Line: lineNum, Column: i + 1},
+			Text:  line[i:end],
+			Value: value,
+			Err:   err,
+		})
+		i = end
+	}
+	skipSpace := func() {
+		i = len(line) - len(strings.TrimLeftFunc(line[i:], unicode.IsSpace))
+	}
+
+	// Each round reads a statement.
+	for {
+		skipSpace()
+		if i == len(line) {
+			return tokens
+		}
+
+		switch {
+		case line[i] == ';':
+			add(TokenSeparator, i+1, ";", nil)
+			continue
+		case strings.HasPrefix(line[i:], "//"):
+			comment := strings.TrimRightFunc(line[i:], unicode.IsSpace)
+			add(TokenComment, i+len(comment), comment, nil)
+			return tokens
+		}
+
+		// Name runs until the first whitespace, comment or end of statement,
+		// or is wrapped in "" like an argument, e.g. `"sv_cheats" "0"`.
+		// Commands without arguments (e.g. "bot_kick") have nothing after it.
+		var name string
+		end := i
+		if line[i] == '"' {
+			close := strings.IndexByte(line[i+1:], '"')
+			if close < 0 {
+				rest := strings.TrimRightFunc(line[i:], unicode.IsSpace)
+				add(TokenInvalid, i+len(rest), rest, ErrDelimiterNotFound{rest})
+				return tokens
+			}
+			name = line[i+1 : i+1+close]
+			end = i + close + 2
+		} else {
+			end = strings.IndexFunc(line[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || r == ';'
+			})
+			if end < 0 {
+				end = len(line) - i
+			}
+			if j := strings.Index(line[i:i+end], "//"); j >= 0 {
+				end = j
+			}
+			end += i
+			name = line[i:end]
+		}
+		if len(name) == 0 || strings.IndexFunc(name, func(r rune) bool { return !isKeyNameRune(r) }) >= 0 {
+			rest := strings.TrimRightFunc(line[i:], unicode.IsSpace)
+			add(TokenInvalid, i+len(rest), rest, ErrInvalidKeyName{rest})
+			return tokens
+		}
+		add(TokenCommand, end, name, nil)
+
+		// Arguments until the end of statement.
+	args:
+		for {
+			skipSpace()
+			if i == len(line) {
+				return tokens
+			}
+
+			switch {
+			case line[i] == ';', strings.HasPrefix(line[i:], "//"):
+				break args
+			case line[i] == '"':
+				end := strings.IndexByte(line[i+1:], '"')
+				// Ended the string without matching quote. Invalid
+				if end < 0 {
+					rest := strings.TrimRightFunc(line[i:], unicode.IsSpace)
+					add(TokenInvalid, i+len(rest), rest, ErrDelimiterNotFound{rest})
+					return tokens
+				}
+				end += i + 1
+				add(TokenQuotedArg, end+1, line[i+1:end], nil)
+				continue
+			}
+
+			end := strings.IndexFunc(line[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || r == '"' || r == ';'
+			})
+			if end < 0 {
+				end = len(line) - i
+			}
+			if j := strings.Index(line[i:i+end], "//"); j >= 0 {
+				end = j
+			}
+			add(TokenArg, i+end, line[i:i+end], nil)
+		}
+	}
+}
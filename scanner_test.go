@@ -0,0 +1,107 @@
+// Copyright 2015 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func scanAll(data string) ([]Token, error) {
+	sc := NewScanner(strings.NewReader(data))
+	var tokens []Token
+	for sc.Scan() {
+		tokens = append(tokens, sc.Token())
+	}
+	return tokens, sc.Err()
+}
+
+func Test_Scanner(t *testing.T) {
+	Convey("Scan tokens of a config", t, func() {
+		tokens, err := scanAll("\xef\xbb\xbf// Match\r\n" +
+			"hostname \"Pro // League; EU\"; mp_maxrounds 30 // MR15\n" +
+			"\n" +
+			"bot_kick")
+		So(err, ShouldBeNil)
+
+		kinds := make([]TokenKind, len(tokens))
+		for i := range tokens {
+			kinds[i] = tokens[i].Kind
+		}
+		So(kinds, ShouldResemble, []TokenKind{
+			TokenComment, TokenNewline,
+			TokenCommand, TokenQuotedArg, TokenSeparator, TokenCommand, TokenArg, TokenComment, TokenNewline,
+			TokenNewline,
+			TokenCommand,
+		})
+
+		So(tokens[0].Pos, ShouldResemble, Position{Offset: 3, Line: 1, Column: 1})
+		So(tokens[1].Text, ShouldEqual, "\r\n")
+		So(tokens[3].Text, ShouldEqual, `"Pro // League; EU"`)
+		So(tokens[3].Value, ShouldEqual, "Pro // League; EU")
+		So(tokens[3].Pos, ShouldResemble, Position{Offset: 22, Line: 2, Column: 10})
+		So(tokens[6].Value, ShouldEqual, "30")
+		So(tokens[7].Value, ShouldEqual, "// MR15")
+		So(tokens[10].Pos, ShouldResemble, Position{Offset: 68, Line: 4, Column: 1})
+		So(TokenQuotedArg.String(), ShouldEqual, "quoted-arg")
+	})
+
+	Convey("Scan lines that cannot be read", t, func() {
+		tokens, err := scanAll("sv_cheats 0; =1\nsay \"hi\nbot_kick")
+		So(err, ShouldBeNil)
+		So(tokens, ShouldHaveLength, 9)
+
+		So(tokens[3].Kind, ShouldEqual, TokenInvalid)
+		So(tokens[3].Pos.Column, ShouldEqual, 14)
+		So(IsErrInvalidKeyName(tokens[3].Err), ShouldBeTrue)
+
+		So(tokens[6].Kind, ShouldEqual, TokenInvalid)
+		So(tokens[6].Text, ShouldEqual, `"hi`)
+		So(IsErrDelimiterNotFound(tokens[6].Err), ShouldBeTrue)
+
+		So(tokens[8].Kind, ShouldEqual, TokenCommand)
+	})
+
+	Convey("Scan names followed by comments and quoted names", t, func() {
+		tokens, err := scanAll("bot_kick// kick\n\"sv_cheats\" \"0\"\n\"mp_maxrounds\"30\n\"sv_\n")
+		So(err, ShouldBeNil)
+		So(tokens, ShouldHaveLength, 11)
+
+		So(tokens[0].Kind, ShouldEqual, TokenCommand)
+		So(tokens[0].Value, ShouldEqual, "bot_kick")
+		So(tokens[1].Kind, ShouldEqual, TokenComment)
+		So(tokens[1].Value, ShouldEqual, "// kick")
+
+		So(tokens[3].Kind, ShouldEqual, TokenCommand)
+		So(tokens[3].Text, ShouldEqual, `"sv_cheats"`)
+		So(tokens[3].Value, ShouldEqual, "sv_cheats")
+		So(tokens[4].Kind, ShouldEqual, TokenQuotedArg)
+		So(tokens[4].Pos.Column, ShouldEqual, 13)
+
+		So(tokens[6].Value, ShouldEqual, "mp_maxrounds")
+		So(tokens[7].Kind, ShouldEqual, TokenArg)
+		So(tokens[7].Value, ShouldEqual, "30")
+
+		So(tokens[9].Kind, ShouldEqual, TokenInvalid)
+		So(IsErrDelimiterNotFound(tokens[9].Err), ShouldBeTrue)
+
+		cfg, err := Load([]byte("bot_kick// kick\n\"sv_cheats\" \"0\"\n"))
+		So(err, ShouldBeNil)
+		So(cfg.Section("").Key("bot_kick").Comment, ShouldEqual, "// kick")
+		So(cfg.Section("").Key("sv_cheats").Value(), ShouldEqual, "0")
+	})
+}
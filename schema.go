@@ -0,0 +1,230 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ConvarType represents type of value a convar holds.
+type ConvarType string
+
+const (
+	ConvarBool    ConvarType = "bool"
+	ConvarInt     ConvarType = "int"
+	ConvarFloat   ConvarType = "float"
+	ConvarString  ConvarType = "string"
+	ConvarCommand ConvarType = "command"
+)
+
+// ConvarFlag represents FCVAR_* flags of a convar, with the same bits as the engine.
+type ConvarFlag uint32
+
+const (
+	FCVAR_DEVELOPMENTONLY        ConvarFlag = 1 << 1
+	FCVAR_GAMEDLL                ConvarFlag = 1 << 2
+	FCVAR_CLIENTDLL              ConvarFlag = 1 << 3
+	FCVAR_HIDDEN                 ConvarFlag = 1 << 4
+	FCVAR_PROTECTED              ConvarFlag = 1 << 5
+	FCVAR_SPONLY                 ConvarFlag = 1 << 6
+	FCVAR_ARCHIVE                ConvarFlag = 1 << 7
+	FCVAR_NOTIFY                 ConvarFlag = 1 << 8
+	FCVAR_USERINFO               ConvarFlag = 1 << 9
+	FCVAR_PRINTABLEONLY          ConvarFlag = 1 << 10
+	FCVAR_UNLOGGED               ConvarFlag = 1 << 11
+	FCVAR_NEVER_AS_STRING        ConvarFlag = 1 << 12
+	FCVAR_REPLICATED             ConvarFlag = 1 << 13
+	FCVAR_CHEAT                  ConvarFlag = 1 << 14
+	FCVAR_SS                     ConvarFlag = 1 << 15
+	FCVAR_DEMO                   ConvarFlag = 1 << 16
+	FCVAR_DONTRECORD             ConvarFlag = 1 << 17
+	FCVAR_SS_ADDED               ConvarFlag = 1 << 18
+	FCVAR_RELEASE                ConvarFlag = 1 << 19
+	FCVAR_RELOAD_MATERIALS       ConvarFlag = 1 << 20
+	FCVAR_RELOAD_TEXTURES        ConvarFlag = 1 << 21
+	FCVAR_NOT_CONNECTED          ConvarFlag = 1 << 22
+	FCVAR_MATERIAL_SYSTEM_THREAD ConvarFlag = 1 << 23
+	FCVAR_ARCHIVE_GAMECONSOLE    ConvarFlag = 1 << 24
+	FCVAR_SERVER_CAN_EXECUTE     ConvarFlag = 1 << 28
+	FCVAR_SERVER_CANNOT_QUERY    ConvarFlag = 1 << 29
+	FCVAR_CLIENTCMD_CAN_EXECUTE  ConvarFlag = 1 << 30
+)
+
+// Convar represents definition of a console variable or command.
+type Convar struct {
+	Name    string
+	Type    ConvarType
+	Default string
+	// Min and Max are only checked when HasMin and HasMax are set.
+	HasMin bool
+	Min    float64
+	HasMax bool
+	Max    float64
+	Flags  ConvarFlag
+	Help   string
+}
+
+// HasFlag returns true if convar has all given flags.
+func (cv *Convar) HasFlag(flag ConvarFlag) bool {
+	return cv.Flags&flag == flag
+}
+
+// Schema represents a set of convar definitions.
+type Schema struct {
+	convars map[string]*Convar
+	names   []string
+}
+
+// NewSchema returns a schema of given convars.
+func NewSchema(convars ...*Convar) *Schema {
+	s := &Schema{
+		convars: make(map[string]*Convar),
+		names:   make([]string, 0, len(convars)),
+	}
+	for _, cv := range convars {
+		s.Add(cv)
+	}
+	return s
+}
+
+// Add adds a convar to schema, replacing any convar with the same name.
+// Names are case-insensitive, as they are in the console.
+func (s *Schema) Add(cv *Convar) {
+	name := strings.ToLower(cv.Name)
+	if _, ok := s.convars[name]; !ok {
+		s.names = append(s.names, name)
+	}
+	s.convars[name] = cv
+}
+
+// Convar returns convar by given name, or nil if it is unknown.
+func (s *Schema) Convar(name string) *Convar {
+	return s.convars[strings.ToLower(name)]
+}
+
+// Convars returns list of convars in the order they were added.
+func (s *Schema) Convars() []*Convar {
+	convars := make([]*Convar, len(s.names))
+	for i := range s.names {
+		convars[i] = s.convars[s.names[i]]
+	}
+	return convars
+}
+
+// DefaultSchema returns the bundled schema of CS:GO convars commonly found in
+// server configs. It is not a complete list; use ParseCvarlist to build one
+// for the exact game build.
+func DefaultSchema() *Schema {
+	return NewSchema(csgoConvars()...)
+}
+
+// ValidationErrorKind is a machine-readable name of a validation error.
+type ValidationErrorKind string
+
+const (
+	ErrKindUnknownConvar ValidationErrorKind = "unknown-convar"
+	ErrKindTypeMismatch  ValidationErrorKind = "type-mismatch"
+	ErrKindOutOfRange    ValidationErrorKind = "out-of-range"
+)
+
+// ValidationError represents a key that does not match its convar definition.
+type ValidationError struct {
+	Key    *Key
+	Convar *Convar
+	Kind   ValidationErrorKind
+}
+
+func (err *ValidationError) Error() string {
+	var msg string
+	switch err.Kind {
+	case ErrKindUnknownConvar:
+		msg = "unknown convar"
+	case ErrKindTypeMismatch:
+		msg = fmt.Sprintf("value '%s' is not %s", err.Key.Value(), err.Convar.Type)
+	case ErrKindOutOfRange:
+		msg = fmt.Sprintf("value '%s' is out of range %s", err.Key.Value(), err.Convar.rangeString())
+	}
+	return fmt.Sprintf("%s: %s: %s", err.Key.Location(), err.Key.Name(), msg)
+}
+
+// ValidationErrors represents all errors found by File.Validate.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i := range errs {
+		msgs[i] = errs[i].Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// rangeString returns range of convar in "[min, max]" format.
+func (cv *Convar) rangeString() string {
+	min, max := "-inf", "inf"
+	if cv.HasMin {
+		min = fmt.Sprint(cv.Min)
+	}
+	if cv.HasMax {
+		max = fmt.Sprint(cv.Max)
+	}
+	return "[" + min + ", " + max + "]"
+}
+
+// validateKey checks value of key against convar definition.
+func (cv *Convar) validateKey(k *Key) *ValidationError {
+	// Commands take any arguments, and a cvar without value only prints it.
+	if cv.Type == ConvarCommand || cv.Type == ConvarString || !k.HasValue() {
+		return nil
+	}
+
+	val, err := k.Float64()
+	if err != nil ||
+		(cv.Type == ConvarInt && val != math.Trunc(val)) ||
+		(cv.Type == ConvarBool && val != 0 && val != 1) {
+		return &ValidationError{k, cv, ErrKindTypeMismatch}
+	}
+
+	min, max := math.Inf(-1), math.Inf(1)
+	if cv.HasMin {
+		min = cv.Min
+	}
+	if cv.HasMax {
+		max = cv.Max
+	}
+	if math.IsNaN(k.RangeFloat64(math.NaN(), min, max)) {
+		return &ValidationError{k, cv, ErrKindOutOfRange}
+	}
+	return nil
+}
+
+// Validate checks every key, including the ones in files loaded by "exec"
+// statements, against given schema. It reports unknown convars, values of
+// wrong type and values out of range, or returns nil if there is none.
+func (f *File) Validate(schema *Schema) ValidationErrors {
+	var errs ValidationErrors
+	f.walkKeys(func(k *Key) {
+		cv := schema.Convar(k.name)
+		if cv == nil {
+			errs = append(errs, &ValidationError{Key: k, Kind: ErrKindUnknownConvar})
+			return
+		}
+		if err := cv.validateKey(k); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errs
+}
@@ -0,0 +1,146 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+// csgoConvars returns definitions of convars and commands commonly found
+// in CS:GO server configs, sorted by name.
+func csgoConvars() []*Convar {
+	return []*Convar{
+		{Name: "alias", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Alias a command."},
+		{Name: "ammo_grenade_limit_default", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Number of grenades of each type a player can carry."},
+		{Name: "ammo_grenade_limit_flashbang", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Number of flashbangs a player can carry."},
+		{Name: "ammo_grenade_limit_total", Type: ConvarInt, Default: "3", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Number of grenades a player can carry in total."},
+		{Name: "bind", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Bind a key."},
+		{Name: "bot_add", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Add a bot."},
+		{Name: "bot_difficulty", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 3, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Bot difficulty, 0 easy to 3 expert."},
+		{Name: "bot_join_after_player", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Bots wait until a player joins before entering the game."},
+		{Name: "bot_kick", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Remove bots from the server."},
+		{Name: "bot_quota", Type: ConvarInt, Default: "10", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Determines the total number of bots in the game."},
+		{Name: "bot_quota_mode", Type: ConvarString, Default: "normal", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "How bots are added, 'normal', 'fill' or 'match'."},
+		{Name: "cash_player_bomb_defused", Type: ConvarInt, Default: "300", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for defusing the bomb."},
+		{Name: "cash_player_bomb_planted", Type: ConvarInt, Default: "300", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for planting the bomb."},
+		{Name: "cash_player_killed_enemy_default", Type: ConvarInt, Default: "300", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for killing an enemy with a default weapon."},
+		{Name: "cash_player_killed_enemy_factor", Type: ConvarFloat, Default: "1", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Factor applied to money awarded for killing an enemy."},
+		{Name: "cash_player_killed_hostage", Type: ConvarInt, Default: "-1000", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for killing a hostage."},
+		{Name: "cash_player_killed_teammate", Type: ConvarInt, Default: "-300", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for killing a teammate."},
+		{Name: "cash_player_rescued_hostage", Type: ConvarInt, Default: "1000", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded for rescuing a hostage."},
+		{Name: "cash_team_elimination_bomb_map", Type: ConvarInt, Default: "3250", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to the team eliminating the enemy on bomb maps."},
+		{Name: "cash_team_elimination_hostage_map_ct", Type: ConvarInt, Default: "2000", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to CTs eliminating the enemy on hostage maps."},
+		{Name: "cash_team_elimination_hostage_map_t", Type: ConvarInt, Default: "1000", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to Ts eliminating the enemy on hostage maps."},
+		{Name: "cash_team_loser_bonus", Type: ConvarInt, Default: "1400", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to the team losing a round."},
+		{Name: "cash_team_loser_bonus_consecutive_rounds", Type: ConvarInt, Default: "500", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Extra money for each consecutive round lost."},
+		{Name: "cash_team_planted_bomb_but_defused", Type: ConvarInt, Default: "800", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to Ts when the planted bomb is defused."},
+		{Name: "cash_team_terrorist_win_bomb", Type: ConvarInt, Default: "3500", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to Ts winning by detonating the bomb."},
+		{Name: "cash_team_win_by_defusing_bomb", Type: ConvarInt, Default: "3500", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to CTs winning by defusing the bomb."},
+		{Name: "cash_team_win_by_hostage_rescue", Type: ConvarInt, Default: "2900", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to CTs winning by rescuing hostages."},
+		{Name: "cash_team_win_by_time_running_out_bomb", Type: ConvarInt, Default: "3250", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to CTs winning when time runs out on bomb maps."},
+		{Name: "cash_team_win_by_time_running_out_hostage", Type: ConvarInt, Default: "3250", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Money awarded to Ts winning when time runs out on hostage maps."},
+		{Name: "echo", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Print text to the console."},
+		{Name: "exec", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Execute a config file."},
+		{Name: "execifexists", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Execute a config file if it exists."},
+		{Name: "ff_damage_reduction_bullets", Type: ConvarFloat, Default: "0.1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "How much to reduce damage done to teammates when shot."},
+		{Name: "ff_damage_reduction_grenade", Type: ConvarFloat, Default: "0.25", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "How much to reduce damage done to teammates by a thrown grenade."},
+		{Name: "ff_damage_reduction_grenade_self", Type: ConvarFloat, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "How much damage a player does to himself with his own grenade."},
+		{Name: "ff_damage_reduction_other", Type: ConvarFloat, Default: "0.25", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "How much to reduce damage done to teammates by things other than bullets and grenades."},
+		{Name: "god", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_CHEAT, Help: "Toggle invulnerability."},
+		{Name: "host_timescale", Type: ConvarFloat, Default: "1", Flags: FCVAR_REPLICATED | FCVAR_CHEAT, Help: "Prescale the clock by this amount."},
+		{Name: "hostname", Type: ConvarString, Default: "Counter-Strike: Global Offensive", Flags: FCVAR_RELEASE, Help: "Hostname for server."},
+		{Name: "mp_afterroundmoney", Type: ConvarInt, Default: "0", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Amount of money awarded to every player after each round."},
+		{Name: "mp_autokick", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Kick idle or team-killing players."},
+		{Name: "mp_autoteambalance", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Balance teams automatically."},
+		{Name: "mp_buy_anywhere", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 3, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Allow players to buy anywhere, 1 for both teams, 2 for Ts and 3 for CTs."},
+		{Name: "mp_buytime", Type: ConvarInt, Default: "90", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How many seconds after round start players can buy items for."},
+		{Name: "mp_c4timer", Type: ConvarInt, Default: "40", HasMin: true, Min: 10, HasMax: true, Max: 90, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How long from when the C4 is armed until it blows."},
+		{Name: "mp_death_drop_defuser", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Drop defuser on player death."},
+		{Name: "mp_death_drop_grenade", Type: ConvarInt, Default: "2", HasMin: true, Min: 0, HasMax: true, Max: 3, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Which grenade to drop on player death."},
+		{Name: "mp_death_drop_gun", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Which gun to drop on player death."},
+		{Name: "mp_defuser_allocation", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How CTs get defusers, 0 none, 1 random and 2 everyone."},
+		{Name: "mp_do_warmup_period", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Play a warmup period before the match."},
+		{Name: "mp_endmatch_votenextmap", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Let players vote for the next map at match end."},
+		{Name: "mp_forcecamera", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Restricts spectator modes for dead players."},
+		{Name: "mp_free_armor", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Give armor and helmet to every player at round start."},
+		{Name: "mp_freezetime", Type: ConvarInt, Default: "6", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How many seconds to keep players frozen when the round starts."},
+		{Name: "mp_friendlyfire", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Allow team members to injure other members of their team."},
+		{Name: "mp_give_player_c4", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Give a T the C4 at round start."},
+		{Name: "mp_halftime", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Switch sides at halftime."},
+		{Name: "mp_halftime_duration", Type: ConvarFloat, Default: "15", HasMin: true, Min: 0, HasMax: true, Max: 300, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of seconds halftime lasts."},
+		{Name: "mp_ignore_round_win_conditions", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Ignore conditions which would end the current round."},
+		{Name: "mp_join_grace_time", Type: ConvarFloat, Default: "0", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of seconds after round start to allow a player to join."},
+		{Name: "mp_limitteams", Type: ConvarInt, Default: "2", HasMin: true, Min: 0, HasMax: true, Max: 30, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Max number of players one team can have over another."},
+		{Name: "mp_match_can_clinch", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "End the match as soon as a team cannot be caught."},
+		{Name: "mp_match_end_restart", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Restart the match at match end."},
+		{Name: "mp_match_restart_delay", Type: ConvarInt, Default: "15", HasMin: true, Min: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Time in seconds until a match restarts."},
+		{Name: "mp_maxmoney", Type: ConvarInt, Default: "16000", HasMin: true, Min: 0, HasMax: true, Max: 65535, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Maximum amount of money allowed in a player's account."},
+		{Name: "mp_maxrounds", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Max number of rounds to play before server changes maps."},
+		{Name: "mp_molotovusedelay", Type: ConvarFloat, Default: "15", HasMin: true, Min: 0, HasMax: true, Max: 30, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of seconds to delay before the molotov can be used."},
+		{Name: "mp_overtime_enable", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Play overtime when the match is tied."},
+		{Name: "mp_overtime_maxrounds", Type: ConvarInt, Default: "6", HasMin: true, Min: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of rounds to play in each overtime."},
+		{Name: "mp_overtime_startmoney", Type: ConvarInt, Default: "10000", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Money each player gets at the start of overtime."},
+		{Name: "mp_playercashawards", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Award money to players for their actions."},
+		{Name: "mp_respawn_on_death_ct", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Respawn CTs on death."},
+		{Name: "mp_respawn_on_death_t", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Respawn Ts on death."},
+		{Name: "mp_restartgame", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Restart the game after the given number of seconds."},
+		{Name: "mp_round_restart_delay", Type: ConvarFloat, Default: "7", HasMin: true, Min: 0, HasMax: true, Max: 14, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of seconds to delay before restarting a round after a win."},
+		{Name: "mp_roundtime", Type: ConvarFloat, Default: "5", HasMin: true, Min: 1, HasMax: true, Max: 60, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How many minutes each round takes."},
+		{Name: "mp_roundtime_defuse", Type: ConvarFloat, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 60, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How many minutes each round of bomb defuse takes."},
+		{Name: "mp_roundtime_hostage", Type: ConvarFloat, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 60, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How many minutes each round of hostage rescue takes."},
+		{Name: "mp_solid_teammates", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Whether teammates are solid."},
+		{Name: "mp_spectators_max", Type: ConvarInt, Default: "2", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of spectators allowed in a competitive match."},
+		{Name: "mp_startmoney", Type: ConvarInt, Default: "800", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Amount of money each player gets when they reset."},
+		{Name: "mp_team_timeout_time", Type: ConvarInt, Default: "60", HasMin: true, Min: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Duration of each team timeout in seconds."},
+		{Name: "mp_teamcashawards", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Award money to teams for their results."},
+		{Name: "mp_teamname_1", Type: ConvarString, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Name of the team starting as CT."},
+		{Name: "mp_teamname_2", Type: ConvarString, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Name of the team starting as T."},
+		{Name: "mp_technical_timeout_per_team", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of technical pauses each team can call."},
+		{Name: "mp_timelimit", Type: ConvarFloat, Default: "5", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Game time per map in minutes."},
+		{Name: "mp_warmup_end", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "End warmup immediately."},
+		{Name: "mp_warmup_pausetimer", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Pause the warmup timer."},
+		{Name: "mp_warmup_start", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Start warmup."},
+		{Name: "mp_warmuptime", Type: ConvarFloat, Default: "30", HasMin: true, Min: 5, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "How long the warmup period lasts."},
+		{Name: "mp_win_panel_display_time", Type: ConvarFloat, Default: "3", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Number of seconds the win panel is shown between rounds."},
+		{Name: "noclip", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_CHEAT, Help: "Toggle noclip mode."},
+		{Name: "rcon_password", Type: ConvarString, Flags: FCVAR_PROTECTED | FCVAR_RELEASE, Help: "Remote console password."},
+		{Name: "say", Type: ConvarCommand, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Display player message."},
+		{Name: "spec_freeze_time", Type: ConvarFloat, Default: "3", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Time spend frozen in observer freeze cam."},
+		{Name: "sv_allow_votes", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Allow voting."},
+		{Name: "sv_alltalk", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Players can hear all other players' voice communication, no team restrictions."},
+		{Name: "sv_cheats", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Allow cheats on server."},
+		{Name: "sv_coaching_enabled", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Allows spectating and communicating with a team."},
+		{Name: "sv_damage_print_enable", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Print damage dealt in the console on death."},
+		{Name: "sv_deadtalk", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Dead players can speak to living players."},
+		{Name: "sv_full_alltalk", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Any player, including spectators, can speak to any other player."},
+		{Name: "sv_gravity", Type: ConvarFloat, Default: "800", Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "World gravity."},
+		{Name: "sv_grenade_trajectory", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_CHEAT, Help: "Show grenade trajectory."},
+		{Name: "sv_hibernate_when_empty", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Put the server in hibernation when no players are connected."},
+		{Name: "sv_infinite_ammo", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_CHEAT, Help: "Player's active weapon will never run out of ammo."},
+		{Name: "sv_kick_players_with_cooldown", Type: ConvarInt, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 2, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Kick players with a competitive cooldown."},
+		{Name: "sv_lan", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Server is a lan server."},
+		{Name: "sv_password", Type: ConvarString, Flags: FCVAR_PROTECTED | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Server password for entry into multiplayer games."},
+		{Name: "sv_pausable", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Is the server pausable."},
+		{Name: "sv_region", Type: ConvarInt, Default: "-1", HasMin: true, Min: -1, HasMax: true, Max: 255, Flags: FCVAR_RELEASE, Help: "The region of the world to report this server in."},
+		{Name: "sv_showimpacts", Type: ConvarInt, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 3, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_CHEAT, Help: "Shows client and server hit locations."},
+		{Name: "sv_tags", Type: ConvarString, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Server tags used to provide extra information to clients when they're browsing for servers."},
+		{Name: "sv_talk_enemy_dead", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Dead players can hear all dead enemy communication."},
+		{Name: "sv_talk_enemy_living", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Living players can hear all living enemy communication."},
+		{Name: "sv_voiceenable", Type: ConvarBool, Default: "1", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_ARCHIVE | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Enable voice communication."},
+		{Name: "tv_delay", Type: ConvarFloat, Default: "10", HasMin: true, Min: 0, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "GOTV broadcast delay in seconds."},
+		{Name: "tv_delaymapchange", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "Delays map change until broadcast is complete."},
+		{Name: "tv_enable", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_NOTIFY | FCVAR_RELEASE, Help: "Activates GOTV on server."},
+		{Name: "tv_name", Type: ConvarString, Default: "GOTV", Flags: FCVAR_GAMEDLL | FCVAR_RELEASE, Help: "GOTV host name."},
+		{Name: "tv_transmitall", Type: ConvarBool, Default: "0", HasMin: true, Min: 0, HasMax: true, Max: 1, Flags: FCVAR_GAMEDLL | FCVAR_REPLICATED | FCVAR_RELEASE, Help: "Transmit all entities, not only director view."},
+		{Name: "unbindall", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Unbind all keys."},
+		{Name: "writeid", Type: ConvarCommand, Flags: FCVAR_RELEASE, Help: "Write a list of permanently-banned user IDs to banned_user.cfg."},
+	}
+}
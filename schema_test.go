@@ -0,0 +1,93 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Schema(t *testing.T) {
+	Convey("Get bundled convars", t, func() {
+		schema := DefaultSchema()
+
+		cv := schema.Convar("mp_roundtime")
+		So(cv, ShouldNotBeNil)
+		So(cv.Type, ShouldEqual, ConvarFloat)
+		So(cv.Default, ShouldEqual, "5")
+		So(cv.Min, ShouldEqual, 1)
+		So(cv.Max, ShouldEqual, 60)
+
+		cv = schema.Convar("SV_CHEATS")
+		So(cv, ShouldNotBeNil)
+		So(cv.HasFlag(FCVAR_NOTIFY|FCVAR_REPLICATED), ShouldBeTrue)
+		So(cv.HasFlag(FCVAR_CHEAT), ShouldBeFalse)
+
+		So(schema.Convar("noclip").HasFlag(FCVAR_CHEAT), ShouldBeTrue)
+		So(schema.Convar("not_a_convar"), ShouldBeNil)
+
+		convars := schema.Convars()
+		for i := 1; i < len(convars); i++ {
+			So(convars[i-1].Name, ShouldBeLessThan, convars[i].Name)
+		}
+	})
+
+	Convey("Validate keys", t, func() {
+		cfg, err := Load([]byte(`hostname "My Server"
+mp_roundtime 1.92
+mp_maxrounds 30.5
+sv_cheats 2
+mp_c4timer 5
+mp_friendlyfire yes
+mp_unknown_cvar 1
+bot_kick
+mp_maxrounds`))
+		So(err, ShouldBeNil)
+
+		errs := cfg.Validate(DefaultSchema())
+		So(errs, ShouldHaveLength, 5)
+
+		So(errs[0].Key.Name(), ShouldEqual, "mp_maxrounds")
+		So(errs[0].Kind, ShouldEqual, ErrKindTypeMismatch)
+		So(errs[1].Key.Name(), ShouldEqual, "sv_cheats")
+		So(errs[1].Kind, ShouldEqual, ErrKindTypeMismatch)
+		So(errs[2].Key.Name(), ShouldEqual, "mp_c4timer")
+		So(errs[2].Kind, ShouldEqual, ErrKindOutOfRange)
+		So(errs[2].Error(), ShouldEqual, "<source 0>:5: mp_c4timer: value '5' is out of range [10, 90]")
+		So(errs[3].Key.Name(), ShouldEqual, "mp_friendlyfire")
+		So(errs[3].Kind, ShouldEqual, ErrKindTypeMismatch)
+		So(errs[4].Key.Name(), ShouldEqual, "mp_unknown_cvar")
+		So(errs[4].Kind, ShouldEqual, ErrKindUnknownConvar)
+		So(errs[4].Convar, ShouldBeNil)
+	})
+
+	Convey("Validate keys in included files", t, func() {
+		cfg, err := LoadSources(LoadOptions{ResolveExec: true}, "testdata/exec/server.cfg")
+		So(err, ShouldBeNil)
+		So(cfg.Validate(DefaultSchema()), ShouldBeNil)
+
+		schema := NewSchema(&Convar{Name: "mp_maxrounds", Type: ConvarInt, HasMax: true, Max: 24})
+		errs := cfg.Validate(schema)
+		So(errs, ShouldNotBeEmpty)
+		var outOfRange []string
+		for _, err := range errs {
+			if err.Kind == ErrKindOutOfRange {
+				outOfRange = append(outOfRange, err.Key.Location().String())
+			}
+		}
+		So(outOfRange, ShouldResemble, []string{"testdata/exec/server.cfg:2", "testdata/exec/esl5on5.cfg:1"})
+	})
+}
@@ -20,6 +20,25 @@ import (
 	"strings"
 )
 
+// node is a single line of a section. Keys, standalone comments and blank
+// lines are all kept in order so they can be written back in place. Lines
+// holding none of them, e.g. a lone ";", are kept as _TOKEN_RAW nodes that
+// are only written with PreserveFormat.
+type node struct {
+	typ     tokenType
+	key     *Key
+	comment string
+	// inline indicates whether the key shares its line with the previous
+	// one, separated by ";".
+	inline bool
+	// conflict holds both sides of a key that failed to merge.
+	conflict *Conflict
+
+	// line and raw are how the node was read, for PreserveFormat.
+	line *rawLine
+	raw  *rawKey
+}
+
 // Section represents a config section.
 type Section struct {
 	f        *File
@@ -28,10 +47,11 @@ type Section struct {
 	keys     map[string]*Key
 	keyList  []string
 	keysHash map[string]string
+	nodes    []*node
 }
 
 func newSection(f *File, name string) *Section {
-	return &Section{f, "", name, make(map[string]*Key), make([]string, 0, 10), make(map[string]string)}
+	return &Section{f, "", name, make(map[string]*Key), make([]string, 0, 10), make(map[string]string), make([]*node, 0, 10)}
 }
 
 // Name returns name of Section.
@@ -39,7 +59,8 @@ func (s *Section) Name() string {
 	return s.name
 }
 
-// NewKey creates a new key to given section.
+// NewKey creates a new key to given section. It returns ErrUnrepresentableValue
+// if value holds a quote or a line break.
 func (s *Section) NewKey(name, val string) (*Key, error) {
 	if len(name) == 0 {
 		return nil, errors.New("error creating new key: empty key name")
@@ -47,24 +68,93 @@ func (s *Section) NewKey(name, val string) (*Key, error) {
 		name = strings.ToLower(name)
 	}
 
+	if !canRepresent(val) {
+		return nil, ErrUnrepresentableValue{name, val}
+	}
+
 	if s.f.BlockMode {
 		s.f.lock.Lock()
 		defer s.f.lock.Unlock()
 	}
 
 	if inSlice(name, s.keyList) {
-		s.keys[name].value = val
+		s.keys[name].setValue(val)
+		s.keysHash[name] = val
 		return s.keys[name], nil
 	}
 
-	s.keyList = append(s.keyList, name)
+	return s.appendKey(name, []Arg{{Value: val}}, false), nil
+}
+
+// appendKey adds a new line of given key at the end of section.
+// An existing key with the same name stays in place but is shadowed by
+// the new one, so repeated commands such as "bind" keep all of their lines.
+// An inline key is written on the same line as the previous key.
+func (s *Section) appendKey(name string, args []Arg, inline bool) *Key {
+	var history []*Key
+	if prev, ok := s.keys[name]; !ok {
+		s.keyList = append(s.keyList, name)
+	} else {
+		history = append(prev.History(), prev)
+	}
 	s.keys[name] = &Key{
-		s:     s,
-		name:  name,
-		value: val,
+		s:       s,
+		name:    name,
+		args:    args,
+		history: history,
+	}
+	s.keysHash[name] = s.keys[name].Value()
+	s.nodes = append(s.nodes, &node{typ: _TOKEN_KEY, key: s.keys[name], inline: inline})
+	return s.keys[name]
+}
+
+// NewComment appends a standalone comment line to given section.
+func (s *Section) NewComment(comment string) {
+	if s.f.BlockMode {
+		s.f.lock.Lock()
+		defer s.f.lock.Unlock()
+	}
+
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, "//") {
+		comment = "// " + comment
+	}
+	s.nodes = append(s.nodes, &node{typ: _TOKEN_COMMENT, comment: comment})
+}
+
+// NewBlankLine appends an empty line to given section.
+func (s *Section) NewBlankLine() {
+	if s.f.BlockMode {
+		s.f.lock.Lock()
+		defer s.f.lock.Unlock()
+	}
+
+	s.nodes = append(s.nodes, &node{typ: _TOKEN_BLANK})
+}
+
+// NewCommand appends a new command with given arguments to given section,
+// e.g. "mp_warmup_end" or "bind MOUSE1 +attack". Unlike NewKey, an existing
+// key with the same name is not changed but shadowed by the new line.
+func (s *Section) NewCommand(name string, args ...string) (*Key, error) {
+	if len(name) == 0 {
+		return nil, errors.New("error creating new command: empty command name")
+	} else if s.f.options.Insensitive {
+		name = strings.ToLower(name)
+	}
+
+	if s.f.BlockMode {
+		s.f.lock.Lock()
+		defer s.f.lock.Unlock()
+	}
+
+	cmdArgs := make([]Arg, len(args))
+	for i := range args {
+		cmdArgs[i].Value = args[i]
+	}
+	if err := checkArgs(name, cmdArgs); err != nil {
+		return nil, err
 	}
-	s.keysHash[name] = val
-	return s.keys[name], nil
+	return s.appendKey(name, cmdArgs, false), nil
 }
 
 // GetKey returns key in section by given name.
@@ -120,7 +210,7 @@ func (s *Section) HasValue(value string) bool {
 	}
 
 	for _, k := range s.keys {
-		if value == k.value {
+		if value == k.Value() {
 			return true
 		}
 	}
@@ -168,6 +258,26 @@ func (s *Section) ParentKeys() []*Key {
 	return parentKeys
 }
 
+// KeysByName returns every line of given key in order, including the ones
+// shadowed by later lines, e.g. all "bind" commands.
+func (s *Section) KeysByName(name string) []*Key {
+	if s.f.BlockMode {
+		s.f.lock.RLock()
+		defer s.f.lock.RUnlock()
+	}
+	if s.f.options.Insensitive {
+		name = strings.ToLower(name)
+	}
+
+	var keys []*Key
+	for _, n := range s.nodes {
+		if n.typ == _TOKEN_KEY && n.key.name == name {
+			keys = append(keys, n.key)
+		}
+	}
+	return keys
+}
+
 // KeyStrings returns list of key names of section.
 func (s *Section) KeyStrings() []string {
 	list := make([]string, len(s.keyList))
@@ -199,8 +309,31 @@ func (s *Section) DeleteKey(name string) {
 	for i, k := range s.keyList {
 		if k == name {
 			s.keyList = append(s.keyList[:i], s.keyList[i+1:]...)
+			s.deleteNodes(func(k *Key) bool { return k.name == name })
 			delete(s.keys, name)
+			delete(s.keysHash, name)
 			return
 		}
 	}
 }
+
+// deleteNodes removes all lines of keys that match.
+func (s *Section) deleteNodes(match func(*Key) bool) {
+	nodes := s.nodes[:0]
+	lineStart := false
+	for _, n := range s.nodes {
+		if n.typ == _TOKEN_KEY && match(n.key) {
+			// The next key sharing the line now starts it.
+			if !n.inline {
+				lineStart = true
+			}
+			continue
+		}
+		if lineStart {
+			n.inline = false
+			lineStart = false
+		}
+		nodes = append(nodes, n)
+	}
+	s.nodes = nodes
+}
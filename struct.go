@@ -194,7 +194,63 @@ func setWithProperType(t reflect.Type, key *Key, field reflect.Value, delim stri
 	return nil
 }
 
-func (s *Section) mapTo(val reflect.Value) error {
+// isNestedStruct returns true if values of type are structs, or pointers
+// to structs, other than time.Time.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// fieldPrefix returns prefix of keys that fields of a nested struct map to,
+// and whether the field holds one. It is the name of a field tagged with
+// "prefix", or the name of any other nested struct followed by "_".
+func fieldPrefix(tpField reflect.StructField, fieldName string, opts []string) (string, bool) {
+	if inSlice("prefix", opts) {
+		return fieldName, true
+	}
+	if isNestedStruct(tpField.Type) {
+		return fieldName + "_", true
+	}
+	return "", false
+}
+
+// hasKeyPrefix returns true if name of any key of section starts with prefix.
+func (s *Section) hasKeyPrefix(prefix string) bool {
+	if s.f.options.Insensitive {
+		prefix = strings.ToLower(prefix)
+	}
+	for _, name := range s.KeyStrings() {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixStruct returns the struct held by a field tagged with "prefix",
+// allocating it if the field is a nil pointer and alloc is true. It returns
+// an invalid value for a nil pointer that is not allocated.
+func prefixStruct(field reflect.Value, alloc bool) (reflect.Value, error) {
+	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+		if field.IsNil() {
+			if !alloc {
+				return reflect.Value{}, nil
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Struct || field.Type().Name() == "Time" {
+		return reflect.Value{}, fmt.Errorf("unsupported type '%s' for prefix", field.Type())
+	}
+	return field, nil
+}
+
+// mapTo maps keys of section to fields of val. Names of keys start with
+// prefix, which is added to by fields tagged with "prefix".
+func (s *Section) mapTo(val reflect.Value, prefix string) error {
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -209,27 +265,28 @@ func (s *Section) mapTo(val reflect.Value) error {
 			continue
 		}
 
-		opts := strings.SplitN(tag, ",", 2) // strip off possible omitempty
+		opts := strings.Split(tag, ",") // strip off possible options
 		fieldName := s.parseFieldName(tpField.Name, opts[0])
 
 		if len(fieldName) == 0 || !field.CanSet() {
 			continue
 		}
 
-		isAnonymous := tpField.Type.Kind() == reflect.Ptr && tpField.Anonymous
-		isStruct := tpField.Type.Kind() == reflect.Struct
-		if isAnonymous {
-			field.Set(reflect.New(tpField.Type.Elem()))
-		}
-
-		if isAnonymous || isStruct {
-			if sec, err := s.f.GetSection(fieldName); err == nil {
-				if err = sec.mapTo(field); err != nil {
-					return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
-				}
+		if fp, ok := fieldPrefix(tpField, fieldName, opts[1:]); ok {
+			// Nil pointers are only allocated for keys to map.
+			inner, err := prefixStruct(field, s.hasKeyPrefix(prefix+fp))
+			if err != nil {
+				return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
+			}
+			if !inner.IsValid() {
 				continue
 			}
+			if err = s.mapTo(inner, prefix+fp); err != nil {
+				return err
+			}
+			continue
 		}
+		fieldName = prefix + fieldName
 
 		if key, err := s.GetKey(fieldName); err == nil {
 			if err = setWithProperType(tpField.Type, key, field, parseDelim(tpField.Tag.Get("delim"))); err != nil {
@@ -240,7 +297,12 @@ func (s *Section) mapTo(val reflect.Value) error {
 	return nil
 }
 
-// MapTo maps section to given struct.
+// MapTo maps section to given struct. Fields of a nested struct tagged
+// with "prefix", e.g. `csgo:"cash_player_,prefix"`, map to keys whose names
+// start with the prefix, such as "cash_player_bomb_defused". Other nested
+// structs use their name followed by "_" as prefix, so a field tagged
+// `csgo:"cash"` maps to "cash_bomb_defused". Nil pointers to nested structs
+// are only allocated if a key with their prefix exists.
 func (s *Section) MapTo(v interface{}) error {
 	typ := reflect.TypeOf(v)
 	val := reflect.ValueOf(v)
@@ -252,7 +314,7 @@ func (s *Section) MapTo(v interface{}) error {
 		return errors.New("cannot map to non-pointer struct")
 	}
 
-	return s.mapTo(val)
+	return s.mapTo(val, "")
 }
 
 // MapTo maps file to given struct.
@@ -350,7 +412,8 @@ func isEmptyValue(v reflect.Value) bool {
 	return false
 }
 
-func (s *Section) reflectFrom(val reflect.Value) error {
+// reflectFrom does the opposite thing as mapTo.
+func (s *Section) reflectFrom(val reflect.Value, prefix string) error {
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -365,8 +428,8 @@ func (s *Section) reflectFrom(val reflect.Value) error {
 			continue
 		}
 
-		opts := strings.SplitN(tag, ",", 2)
-		if len(opts) == 2 && opts[1] == "omitempty" && isEmptyValue(field) {
+		opts := strings.Split(tag, ",")
+		if inSlice("omitempty", opts[1:]) && isEmptyValue(field) {
 			continue
 		}
 
@@ -375,19 +438,20 @@ func (s *Section) reflectFrom(val reflect.Value) error {
 			continue
 		}
 
-		if (tpField.Type.Kind() == reflect.Ptr && tpField.Anonymous) ||
-			(tpField.Type.Kind() == reflect.Struct && tpField.Type.Name() != "Time") {
-			// Note: The only error here is section doesn't exist.
-			sec, err := s.f.GetSection(fieldName)
+		if fp, ok := fieldPrefix(tpField, fieldName, opts[1:]); ok {
+			inner, err := prefixStruct(field, false)
 			if err != nil {
-				// Note: fieldName can never be empty here, ignore error.
-				sec, _ = s.f.NewSection(fieldName)
-			}
-			if err = sec.reflectFrom(field); err != nil {
 				return fmt.Errorf("error reflecting field (%s): %v", fieldName, err)
 			}
+			if !inner.IsValid() {
+				continue
+			}
+			if err = s.reflectFrom(inner, prefix+fp); err != nil {
+				return err
+			}
 			continue
 		}
+		fieldName = prefix + fieldName
 
 		// Note: Same reason as secion.
 		key, err := s.GetKey(fieldName)
@@ -402,7 +466,8 @@ func (s *Section) reflectFrom(val reflect.Value) error {
 	return nil
 }
 
-// ReflectFrom reflects secion from given struct.
+// ReflectFrom reflects secion from given struct. Fields of nested structs
+// are written as keys with their prefix, like MapTo.
 func (s *Section) ReflectFrom(v interface{}) error {
 	typ := reflect.TypeOf(v)
 	val := reflect.ValueOf(v)
@@ -413,7 +478,7 @@ func (s *Section) ReflectFrom(v interface{}) error {
 		return errors.New("cannot reflect from non-pointer struct")
 	}
 
-	return s.reflectFrom(val)
+	return s.reflectFrom(val, "")
 }
 
 // ReflectFrom reflects file from given struct.
@@ -59,6 +59,38 @@ Here "there"
 When "then"
 `
 
+type cashPlayer struct {
+	BombDefused int `csgo:"bomb_defused"`
+	BombPlanted int `csgo:"bomb_planted"`
+}
+
+type grenadeLimits struct {
+	Default   int `csgo:"default"`
+	Flashbang int `csgo:"flashbang"`
+	Total     int `csgo:"total"`
+}
+
+type prefixedStruct struct {
+	BotQuota int            `csgo:"bot_quota"`
+	Cash     cashPlayer     `csgo:"cash_player_,prefix"`
+	Ammo     *grenadeLimits `csgo:"ammo_grenade_limit_,prefix,omitempty"`
+}
+
+type namedNestedStruct struct {
+	BombDefused int        `csgo:"bomb_defused"`
+	Plain       cashPlayer `csgo:"plain"`
+}
+
+const _CONF_DATA_PREFIX = `
+ammo_grenade_limit_default 1
+ammo_grenade_limit_flashbang 2
+ammo_grenade_limit_total 4
+bot_quota "0"
+cash_player_bomb_defused 300
+cash_player_bomb_planted 300
+ammo_grenade_limit_total 5
+`
+
 type unsupport struct {
 	Byte byte
 }
@@ -161,6 +193,32 @@ func Test_Struct(t *testing.T) {
 			So(ts.Omitted, ShouldEqual, true)
 		})
 
+		Convey("Map to nested structs by prefix", func() {
+			ps := new(prefixedStruct)
+			So(MapTo(ps, []byte(_CONF_DATA_PREFIX)), ShouldBeNil)
+
+			So(ps.Cash.BombDefused, ShouldEqual, 300)
+			So(ps.Cash.BombPlanted, ShouldEqual, 300)
+			So(ps.Ammo, ShouldNotBeNil)
+			So(ps.Ammo.Flashbang, ShouldEqual, 2)
+			So(ps.Ammo.Total, ShouldEqual, 5)
+
+			So(MapTo(&struct {
+				Quota int `csgo:"bot_quota,prefix"`
+			}{}, []byte(_CONF_DATA_PREFIX)), ShouldNotBeNil)
+
+			ps = new(prefixedStruct)
+			So(MapTo(ps, []byte("bot_quota 5")), ShouldBeNil)
+			So(ps.Ammo, ShouldBeNil)
+		})
+
+		Convey("Map to nested structs by field name", func() {
+			ns := new(namedNestedStruct)
+			So(MapTo(ns, []byte("bomb_defused 1\nplain_bomb_defused 5")), ShouldBeNil)
+			So(ns.BombDefused, ShouldEqual, 1)
+			So(ns.Plain.BombDefused, ShouldEqual, 5)
+		})
+
 		Convey("Map from invalid data source", func() {
 			So(MapTo(&testStruct{}, "hi"), ShouldNotBeNil)
 		})
@@ -216,6 +274,34 @@ func Test_Struct(t *testing.T) {
 			So(ReflectFrom(cfg, Author{}), ShouldNotBeNil)
 		})
 
+		Convey("Reflect from nested structs by prefix", func() {
+			cfg, err := Load([]byte(_CONF_DATA_PREFIX))
+			So(err, ShouldBeNil)
+
+			ps := &prefixedStruct{BotQuota: 10, Cash: cashPlayer{BombDefused: 250, BombPlanted: 800}}
+			So(ReflectFrom(cfg, ps), ShouldBeNil)
+			So(cfg.Section("").Key("bot_quota").Value(), ShouldEqual, "10")
+			So(cfg.Section("").Key("cash_player_bomb_defused").Value(), ShouldEqual, "250")
+			So(cfg.Section("").Key("cash_player_bomb_planted").Value(), ShouldEqual, "800")
+			So(cfg.Section("").Key("ammo_grenade_limit_total").Value(), ShouldEqual, "5")
+
+			ps.Ammo = &grenadeLimits{1, 1, 3}
+			So(ReflectFrom(cfg, ps), ShouldBeNil)
+			So(cfg.Section("").Key("ammo_grenade_limit_total").Value(), ShouldEqual, "3")
+			So(cfg.Section("").HasKey("default"), ShouldBeFalse)
+		})
+
+		Convey("Reflect from nested structs by field name", func() {
+			cfg := Empty()
+			So(cfg.ReflectFrom(&namedNestedStruct{BombDefused: 1, Plain: cashPlayer{BombDefused: 5}}), ShouldBeNil)
+			So(cfg.Section("").KeyStrings(), ShouldResemble, []string{"bomb_defused", "plain_bomb_defused", "plain_bomb_planted"})
+			So(cfg.Section("").Key("bomb_defused").Value(), ShouldEqual, "1")
+
+			ns := new(namedNestedStruct)
+			So(cfg.MapTo(ns), ShouldBeNil)
+			So(ns.Plain.BombDefused, ShouldEqual, 5)
+		})
+
 		Convey("Reflect from struct with omitempty", func() {
 			cfg := Empty()
 			type SpecialStruct struct {
@@ -0,0 +1,123 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"strings"
+
+	cfg "github.com/metalmichael/go-csgo-cfg"
+)
+
+// gameModesRoot returns the block holding "gameTypes" of a gamemodes
+// document, which is either the document or its first block.
+func (n *Node) gameModesRoot() *Node {
+	if n.Child("gameTypes") == nil {
+		if root := n.firstBlock(); root != nil {
+			return root
+		}
+	}
+	return n
+}
+
+// Convars returns convar overrides of given game type and mode of a
+// gamemodes_server.txt document, e.g. "classic" and "competitive", as a
+// config. Comments and blank lines of the block are kept. Overrides with
+// a conditional are left out, as a config cannot hold them.
+func (n *Node) Convars(gameType, gameMode string) (*cfg.File, error) {
+	block, err := n.gameModesRoot().GetChild("gameTypes", gameType, "gameModes", gameMode, "convars")
+	if err != nil {
+		return nil, err
+	}
+
+	f := cfg.Empty()
+	sec := f.Section("")
+	for _, cn := range block.children {
+		switch {
+		case cn.typ == _NODE_BLANK:
+			sec.NewBlankLine()
+		case cn.typ == _NODE_COMMENT:
+			sec.NewComment(cn.Comment)
+		case cn.typ == _NODE_VALUE && len(cn.Condition) == 0:
+			k, err := sec.NewKey(cn.Name, cn.value)
+			if err != nil {
+				return nil, err
+			}
+			k.Comment = cn.Comment
+		}
+	}
+	return f, nil
+}
+
+// SetConvars writes keys of f back as convar overrides of given game type
+// and mode, creating blocks that do not exist. Overrides keep their place,
+// ones f does not have are deleted, and new ones are added at the end of
+// the block. Overrides with a conditional, standalone comments and blank
+// lines are left as they are, and only changed overrides are rewritten when
+// the document is written.
+func (n *Node) SetConvars(gameType, gameMode string, f *cfg.File) error {
+	keys := make(map[string]*cfg.Key)
+	for _, k := range f.Section("").Keys() {
+		if err := k.CheckValue(); err != nil {
+			return err
+		}
+		keys[strings.ToLower(k.Name())] = k
+	}
+
+	block := n.gameModesRoot().Block("gameTypes").Block(gameType).Block("gameModes").Block(gameMode).Block("convars")
+	nodes := block.children[:0]
+	written := make(map[string]bool)
+	for _, cn := range block.children {
+		if cn.typ != _NODE_VALUE || len(cn.Condition) > 0 {
+			nodes = append(nodes, cn)
+			continue
+		}
+
+		name := strings.ToLower(cn.Name)
+		k := keys[name]
+		if k == nil || written[name] {
+			continue
+		}
+		written[name] = true
+		cn.value = k.Value()
+		cn.Comment = convarComment(k)
+		nodes = append(nodes, cn)
+	}
+	block.children = nodes
+
+	for _, k := range f.Section("").Keys() {
+		name := strings.ToLower(k.Name())
+		if written[name] {
+			continue
+		}
+		written[name] = true
+		k = keys[name]
+		// Not SetChild, which could match an override with a conditional.
+		block.children = append(block.children, &Node{
+			typ:     _NODE_VALUE,
+			Name:    k.Name(),
+			Comment: convarComment(k),
+			value:   k.Value(),
+		})
+	}
+	return nil
+}
+
+// convarComment returns comment of key with "//" prefix.
+func convarComment(k *cfg.Key) string {
+	if len(k.Comment) == 0 || strings.HasPrefix(k.Comment, "//") {
+		return k.Comment
+	}
+	return "// " + k.Comment
+}
@@ -0,0 +1,94 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Convars(t *testing.T) {
+	Convey("Get convars of a game mode", t, func() {
+		doc, err := Load("testdata/gamemodes_server.txt")
+		So(err, ShouldBeNil)
+
+		f, err := doc.Convars("classic", "competitive")
+		So(err, ShouldBeNil)
+		sec := f.Section("")
+		So(sec.KeyStrings(), ShouldResemble, []string{"mp_maxrounds", "mp_freezetime"})
+		So(sec.Key("mp_maxrounds").MustInt(), ShouldEqual, 30)
+		So(sec.Key("mp_maxrounds").Comment, ShouldEqual, "// MR15")
+
+		_, err = doc.Convars("classic", "casual")
+		So(IsErrNodeNotFound(err), ShouldBeTrue)
+
+		Convey("Write convars back", func() {
+			sec.Key("mp_maxrounds").SetValue("24")
+			sec.DeleteKey("mp_freezetime")
+			sec.NewKey("mp_halftime", "1")
+			sec.NewKey("sv_allow_votes", "1")
+			So(doc.SetConvars("classic", "competitive", f), ShouldBeNil)
+
+			var buf bytes.Buffer
+			_, err := doc.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, strings.Replace(`"convars"
+					{
+						"mp_maxrounds"		"24" // MR15
+						"sv_allow_votes"		"0" [$WIN32]
+						"sv_allow_votes"		"1" [!$WIN32]
+						"mp_halftime"		"1"
+						"sv_allow_votes"		"1"
+					}`, "\n", LineBreak, -1))
+			So(buf.String(), ShouldContainSubstring, `"mapgroups"`)
+
+			sec.NewKey("mp_freezetime", "15")
+			sec.Key("mp_freezetime").Comment = "seconds"
+			So(doc.SetConvars("classic", "casual", f), ShouldBeNil)
+			casual, err := doc.Convars("classic", "casual")
+			So(err, ShouldBeNil)
+			So(casual.Section("").KeysHash(), ShouldResemble, f.Section("").KeysHash())
+			So(casual.Section("").Key("mp_freezetime").Comment, ShouldEqual, "// seconds")
+		})
+	})
+
+	Convey("Write convars back to a document with its own layout", t, func() {
+		data, err := os.ReadFile("testdata/gamemodes_server_raw.txt")
+		So(err, ShouldBeNil)
+		doc, err := Load("testdata/gamemodes_server_raw.txt")
+		So(err, ShouldBeNil)
+
+		f, err := doc.Convars("classic", "competitive")
+		So(err, ShouldBeNil)
+		sec := f.Section("")
+		sec.Key("mp_maxrounds").SetValue("24")
+		sec.DeleteKey("mp_freezetime")
+		sec.NewKey("mp_halftime", "1")
+		So(doc.SetConvars("classic", "competitive", f), ShouldBeNil)
+
+		var buf bytes.Buffer
+		_, err = doc.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		want := strings.Replace(string(data), "mp_maxrounds   30   // MR15", `mp_maxrounds   "24"   // MR15`, 1)
+		want = strings.Replace(want, "\t      \"mp_freezetime\"\t15\n", "", 1)
+		want = strings.Replace(want, "\t      sv_allow_votes\t\"1\"\t[!$WIN32]\n",
+			"\t      sv_allow_votes\t\"1\"\t[!$WIN32]\n\t\t\t\t\t\t\"mp_halftime\"\t\t\"1\""+LineBreak, 1)
+		So(buf.String(), ShouldEqual, want)
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"bytes"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	_TOKEN_EOF tokenType = iota
+	_TOKEN_STRING
+	_TOKEN_OPEN
+	_TOKEN_CLOSE
+	_TOKEN_CONDITION
+	_TOKEN_COMMENT
+	_TOKEN_NEWLINE
+)
+
+type token struct {
+	typ    tokenType
+	text   string
+	line   int
+	quoted bool
+	// Spaces before the token and the token as written.
+	space, raw string
+}
+
+type parser struct {
+	data   []byte
+	pos    int
+	line   int
+	peeked *token
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// next returns the next token. Quoted strings run until the next quote,
+// even across lines, as there are no escape sequences.
+func (p *parser) next() (token, error) {
+	if p.peeked != nil {
+		tok := *p.peeked
+		p.peeked = nil
+		return tok, nil
+	}
+
+	spaceStart := p.pos
+	for p.pos < len(p.data) && isSpace(p.data[p.pos]) {
+		p.pos++
+	}
+	tok := token{line: p.line, space: string(p.data[spaceStart:p.pos])}
+	if p.pos == len(p.data) {
+		return tok, nil
+	}
+
+	start := p.pos
+	rest := p.data[p.pos:]
+	switch {
+	case rest[0] == '\n':
+		tok.typ = _TOKEN_NEWLINE
+		p.pos++
+		p.line++
+	case rest[0] == '{':
+		tok.typ = _TOKEN_OPEN
+		p.pos++
+	case rest[0] == '}':
+		tok.typ = _TOKEN_CLOSE
+		p.pos++
+	case bytes.HasPrefix(rest, []byte("//")):
+		end := bytes.IndexByte(rest, '\n')
+		if end < 0 {
+			end = len(rest)
+		}
+		tok.typ = _TOKEN_COMMENT
+		tok.text = strings.TrimRight(string(rest[:end]), " \t\r")
+		p.pos += end
+	case rest[0] == '[':
+		end := bytes.IndexAny(rest, "]\n")
+		if end < 0 || rest[end] != ']' {
+			return tok, ErrSyntax{p.line, "conditional without closing ']'"}
+		}
+		tok.typ = _TOKEN_CONDITION
+		tok.text = strings.TrimSpace(string(rest[1:end]))
+		p.pos += end + 1
+	case rest[0] == '"':
+		end := bytes.IndexByte(rest[1:], '"')
+		if end < 0 {
+			return tok, ErrSyntax{p.line, "string without closing quote"}
+		}
+		tok.typ = _TOKEN_STRING
+		tok.text = string(rest[1 : end+1])
+		tok.quoted = true
+		p.pos += end + 2
+		p.line += strings.Count(tok.text, "\n")
+	default:
+		end := 0
+		for end < len(rest) && !isSpace(rest[end]) && bytes.IndexByte([]byte("\n{}\"["), rest[end]) < 0 &&
+			!bytes.HasPrefix(rest[end:], []byte("//")) {
+			end++
+		}
+		tok.typ = _TOKEN_STRING
+		tok.text = string(rest[:end])
+		p.pos += end
+	}
+	tok.raw = string(p.data[start:p.pos])
+	return tok, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *parser) peek() (token, error) {
+	tok, err := p.next()
+	if err != nil {
+		return tok, err
+	}
+	p.peeked = &tok
+	return tok, nil
+}
+
+// parseBlock parses nodes into block until its closing brace, or the end
+// of data for the top level.
+func (p *parser) parseBlock(block *Node, top bool) error {
+	// Blank lines are lines that start with a line break. A block starts
+	// on the line of its opening brace.
+	lineStart := top
+	// Last node on the current line, to take a comment after it.
+	var last *Node
+	// Source text up to the next node belongs to the last one read.
+	rest := &block.src.tail
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		switch tok.typ {
+		case _TOKEN_EOF:
+			if !top {
+				return ErrSyntax{block.line, "block '" + block.Name + "' without closing '}'"}
+			}
+			*rest += tok.space
+			return nil
+		case _TOKEN_CLOSE:
+			if top {
+				return ErrSyntax{tok.line, "unexpected '}'"}
+			}
+			block.src.close = tok.space + tok.raw
+			return nil
+		case _TOKEN_NEWLINE:
+			if lineStart {
+				n := &Node{typ: _NODE_BLANK, line: tok.line}
+				n.src = newSource(n, tok.space+tok.raw)
+				block.children = append(block.children, n)
+			} else {
+				*rest += tok.space + tok.raw
+			}
+			lineStart, last = true, nil
+			continue
+		case _TOKEN_COMMENT:
+			if last != nil {
+				last.Comment = tok.text
+				last.src.comment = tok.text
+				*rest += tok.space + tok.raw
+			} else {
+				n := &Node{typ: _NODE_COMMENT, Comment: tok.text, line: tok.line}
+				n.src = newSource(n, tok.space+tok.raw)
+				block.children = append(block.children, n)
+				rest = &n.src.tail
+			}
+		case _TOKEN_STRING:
+			n, err := p.parsePair(tok)
+			if err != nil {
+				return err
+			}
+			block.children = append(block.children, n)
+			last, rest = n, &n.src.tail
+			if n.typ == _NODE_BLOCK {
+				last, rest = nil, &n.src.close
+			}
+		default:
+			return ErrSyntax{tok.line, "expected a key"}
+		}
+		lineStart = false
+	}
+}
+
+// parsePair parses value or block of given key.
+func (p *parser) parsePair(key token) (*Node, error) {
+	n := &Node{Name: key.text, line: key.line}
+	if !key.quoted && (strings.EqualFold(key.text, "#base") || strings.EqualFold(key.text, "#include")) {
+		n.typ = _NODE_DIRECTIVE
+	}
+	head := key.space + key.raw
+
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok.typ {
+		case _TOKEN_NEWLINE:
+		case _TOKEN_COMMENT:
+			n.Comment = tok.text
+		case _TOKEN_CONDITION:
+			n.Condition = tok.text
+		case _TOKEN_OPEN:
+			if n.typ == _NODE_DIRECTIVE {
+				return nil, ErrSyntax{tok.line, "expected file name after '" + n.Name + "'"}
+			}
+			n.typ = _NODE_BLOCK
+			n.src = newSource(n, head+tok.space+tok.raw)
+			return n, p.parseBlock(n, false)
+		case _TOKEN_STRING:
+			n.value = tok.text
+			n.src = newSource(n, head+tok.space)
+			n.src.value = tok.raw
+			// Conditional after the value, on the same line.
+			if next, err := p.peek(); err != nil {
+				return nil, err
+			} else if next.typ == _TOKEN_CONDITION {
+				p.peeked = nil
+				n.Condition = next.text
+				n.src.cond = next.text
+				n.src.tail = next.space + next.raw
+			}
+			return n, nil
+		default:
+			return nil, ErrSyntax{tok.line, "expected a value or '{' after '" + n.Name + "'"}
+		}
+		head += tok.space + tok.raw
+	}
+}
+
+// parse parses a document from data.
+func parse(data []byte) (*Node, error) {
+	// Skip BOM-UTF8 header.
+	bom := []byte("\xef\xbb\xbf")
+	doc := NewDocument()
+	doc.src = &source{top: true}
+	if bytes.HasPrefix(data, bom) {
+		doc.src.head = string(bom)
+		data = data[len(bom):]
+	}
+
+	p := &parser{data: data, line: 1}
+	if err := p.parseBlock(doc, true); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
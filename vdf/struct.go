@@ -0,0 +1,234 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseFieldTag returns node name and whether to omit empty values
+// of a field from its "vdf" tag.
+func parseFieldTag(field reflect.StructField) (string, bool) {
+	opts := strings.SplitN(field.Tag.Get("vdf"), ",", 2)
+	name := opts[0]
+	if len(name) == 0 {
+		name = field.Name
+	}
+	return name, len(opts) == 2 && opts[1] == "omitempty"
+}
+
+// setWithProperType sets value of node to field based on its type. Like
+// struct mapping of configs, it does not return error for failing parsing,
+// because we want to use default value that is already assigned to struct.
+func setWithProperType(n *Node, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Struct:
+		if !n.IsBlock() {
+			return fmt.Errorf("'%s' is not a block", n.Name)
+		}
+		return n.mapTo(field)
+	case reflect.Ptr:
+		if field.Type().Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("unsupported type '%s'", field.Type())
+		}
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setWithProperType(n, field.Elem())
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type '%s'", field.Type())
+		}
+		if !n.IsBlock() {
+			return fmt.Errorf("'%s' is not a block", n.Name)
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		for _, cn := range n.Children() {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setWithProperType(cn, elem); err != nil {
+				return err
+			}
+			field.SetMapIndex(reflect.ValueOf(cn.Name).Convert(field.Type().Key()), elem)
+		}
+	case reflect.String:
+		field.SetString(n.value)
+	case reflect.Bool:
+		if boolVal, err := n.Bool(); err == nil {
+			field.SetBool(boolVal)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if intVal, err := strconv.ParseInt(n.value, 10, field.Type().Bits()); err == nil {
+			field.SetInt(intVal)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if uintVal, err := strconv.ParseUint(n.value, 10, field.Type().Bits()); err == nil {
+			field.SetUint(uintVal)
+		}
+	case reflect.Float32, reflect.Float64:
+		if floatVal, err := strconv.ParseFloat(n.value, field.Type().Bits()); err == nil {
+			field.SetFloat(floatVal)
+		}
+	default:
+		return fmt.Errorf("unsupported type '%s'", field.Type())
+	}
+	return nil
+}
+
+func (n *Node) mapTo(val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		tpField := typ.Field(i)
+		if tpField.Tag.Get("vdf") == "-" || !field.CanSet() {
+			continue
+		}
+
+		name, _ := parseFieldTag(tpField)
+		cn := n.Child(name)
+		if cn == nil {
+			continue
+		}
+		if err := setWithProperType(cn, field); err != nil {
+			return fmt.Errorf("error mapping field(%s): %v", name, err)
+		}
+	}
+	return nil
+}
+
+// MapTo maps child nodes of a block to given struct. Fields are matched by
+// names from "vdf" tags, or field names, ignoring case. Struct fields map
+// to blocks, and maps with string keys to blocks of any names, e.g. the
+// maps of a map group.
+func (n *Node) MapTo(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return errors.New("cannot map to non-pointer struct")
+	}
+	return n.mapTo(val.Elem())
+}
+
+// MapTo maps a document file to given struct.
+func MapTo(v interface{}, filename string) error {
+	doc, err := Load(filename)
+	if err != nil {
+		return err
+	}
+	return doc.MapTo(v)
+}
+
+// isEmptyValue returns true if field holds zero value of its type.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// reflectWithProperType does the opposite thing as setWithProperType,
+// setting child node of given name.
+func reflectWithProperType(n *Node, name string, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Struct:
+		return n.Block(name).reflectFrom(field)
+	case reflect.Ptr:
+		if field.Type().Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("unsupported type '%s'", field.Type())
+		}
+		if field.IsNil() {
+			return nil
+		}
+		return n.Block(name).reflectFrom(field.Elem())
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type '%s'", field.Type())
+		}
+		block := n.Block(name)
+		keys := make([]string, 0, field.Len())
+		for _, k := range field.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			elem := field.MapIndex(reflect.ValueOf(k).Convert(field.Type().Key()))
+			if err := reflectWithProperType(block, k, elem); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		n.SetChild(name, field.String())
+	case reflect.Bool:
+		if field.Bool() {
+			n.SetChild(name, "1")
+		} else {
+			n.SetChild(name, "0")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.SetChild(name, strconv.FormatInt(field.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n.SetChild(name, strconv.FormatUint(field.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		n.SetChild(name, strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits()))
+	default:
+		return fmt.Errorf("unsupported type '%s'", field.Type())
+	}
+	return nil
+}
+
+func (n *Node) reflectFrom(val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		tpField := typ.Field(i)
+		// Map elements are not addressable, so check for unexported
+		// fields instead of settable ones.
+		if tpField.Tag.Get("vdf") == "-" || len(tpField.PkgPath) > 0 {
+			continue
+		}
+
+		name, omitEmpty := parseFieldTag(tpField)
+		if omitEmpty && isEmptyValue(field) {
+			continue
+		}
+		// Keep the name as it is written when the node exists.
+		if cn := n.Child(name); cn != nil {
+			name = cn.Name
+		}
+		if err := reflectWithProperType(n, name, field); err != nil {
+			return fmt.Errorf("error reflecting field(%s): %v", name, err)
+		}
+	}
+	return nil
+}
+
+// ReflectFrom sets child nodes of a block from given struct, the opposite
+// of MapTo. Nodes that exist keep their place, and others are added at the
+// end of their block.
+func (n *Node) ReflectFrom(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return errors.New("cannot reflect from non-pointer struct")
+	}
+	return n.reflectFrom(val.Elem())
+}
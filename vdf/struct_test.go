@@ -0,0 +1,93 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testGameMode struct {
+	MaxPlayers int               `vdf:"maxplayers"`
+	Exec       *testExec         `vdf:"exec"`
+	Convars    map[string]string `vdf:"convars"`
+}
+
+type testExec struct {
+	Exec string
+}
+
+type testMapGroup struct {
+	Name string            `vdf:"name"`
+	Maps map[string]string `vdf:"maps"`
+}
+
+type testGameModes struct {
+	GameTypes struct {
+		Classic struct {
+			GameModes map[string]testGameMode
+		}
+	}
+	MapGroups map[string]*testMapGroup `vdf:"mapgroups"`
+	Unused    string                   `vdf:"-"`
+	Bots      bool                     `vdf:"bots,omitempty"`
+}
+
+func Test_Struct(t *testing.T) {
+	Convey("Map document to struct", t, func() {
+		doc, err := Load("testdata/gamemodes_server.txt")
+		So(err, ShouldBeNil)
+
+		modes := new(testGameModes)
+		So(doc.Child("GameModes_Server.txt").MapTo(modes), ShouldBeNil)
+
+		comp := modes.GameTypes.Classic.GameModes["competitive"]
+		So(comp.MaxPlayers, ShouldEqual, 10)
+		So(comp.Exec.Exec, ShouldEqual, "server_competitive.cfg")
+		So(comp.Convars["mp_freezetime"], ShouldEqual, "15")
+		So(modes.MapGroups["mg_active"].Maps, ShouldHaveLength, 2)
+
+		So(doc.MapTo(&struct{ X int }{}), ShouldBeNil)
+		So(doc.MapTo(testGameModes{}), ShouldNotBeNil)
+		So(doc.MapTo(&struct {
+			Root int `vdf:"GameModes_Server.txt"`
+		}{}), ShouldBeNil)
+
+		Convey("Reflect struct back to document", func() {
+			comp.MaxPlayers = 12
+			comp.Convars["mp_maxrounds"] = "24"
+			comp.Convars["mp_halftime"] = "1"
+			modes.GameTypes.Classic.GameModes["competitive"] = comp
+			modes.MapGroups["mg_active"].Maps["de_nuke"] = ""
+			modes.Bots = true
+
+			root := doc.Child("GameModes_Server.txt")
+			So(root.ReflectFrom(modes), ShouldBeNil)
+
+			mode, err := root.GetChild("gameTypes", "classic", "gameModes", "competitive")
+			So(err, ShouldBeNil)
+			So(mode.Child("maxplayers").Value(), ShouldEqual, "12")
+			So(mode.Child("convars").Child("mp_maxrounds").Value(), ShouldEqual, "24")
+			So(mode.Child("convars").Child("mp_maxrounds").Comment, ShouldEqual, "// MR15")
+			So(mode.Child("convars").Children()[4].Name, ShouldEqual, "mp_halftime")
+			So(root.Child("mapgroups").Child("mg_active").Child("maps").Children(), ShouldHaveLength, 3)
+			So(root.Child("bots").Value(), ShouldEqual, "1")
+			So(root.Child("Unused"), ShouldBeNil)
+
+			So(root.ReflectFrom(*modes), ShouldNotBeNil)
+		})
+	})
+}
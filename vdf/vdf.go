@@ -0,0 +1,564 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package vdf reads and writes Valve's KeyValues format, used by files such
+// as gamemodes_server.txt, subscribed_collection_ids.txt and botprofile.db.
+//
+// A document is a tree of nodes, each of which holds either a value or a
+// block of child nodes:
+//
+//	"GameModes_Server.txt"
+//	{
+//		"gameTypes"
+//		{
+//			"classic"
+//			{
+//				"maxplayers"	"10"	[$WIN32]
+//			}
+//		}
+//	}
+//
+// Names are case-insensitive, as they are in the engine. Comments and blank
+// lines are kept so that a document can be edited and written back, and
+// nodes that are not changed are written with the spacing they were read
+// with.
+//
+// https://developer.valvesoftware.com/wiki/KeyValues
+package vdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Delimiter to determine or compose a new line.
+// This variable will be changed to "\r\n" automatically on Windows
+// at package init time.
+var LineBreak = "\n"
+
+func init() {
+	if runtime.GOOS == "windows" {
+		LineBreak = "\r\n"
+	}
+}
+
+type nodeType int
+
+const (
+	_NODE_VALUE nodeType = iota
+	_NODE_BLOCK
+	_NODE_COMMENT
+	_NODE_BLANK
+	// #base and #include statements.
+	_NODE_DIRECTIVE
+)
+
+type ErrSyntax struct {
+	Line int
+	Msg  string
+}
+
+func IsErrSyntax(err error) bool {
+	return errors.As(err, new(ErrSyntax))
+}
+
+func (err ErrSyntax) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Msg)
+}
+
+type ErrNodeNotFound struct {
+	Path []string
+}
+
+func IsErrNodeNotFound(err error) bool {
+	return errors.As(err, new(ErrNodeNotFound))
+}
+
+func (err ErrNodeNotFound) Error() string {
+	return fmt.Sprintf("node '%s' does not exist", strings.Join(err.Path, "/"))
+}
+
+// Node represents a key of a document, with either a value or child nodes.
+type Node struct {
+	typ nodeType
+	// Name is the key, e.g. "maxplayers".
+	Name string
+	// Condition is the platform conditional of the node without brackets,
+	// e.g. "$WIN32" or "!$X360", or empty if it has none.
+	Condition string
+	// Comment is the comment on the line of the node, with "//" prefix.
+	Comment string
+
+	value    string
+	children []*Node
+	line     int
+	// Source text of node, or nil if it was not read from a document.
+	src *source
+}
+
+// source holds text that a node was read from, so that the node can be
+// written back as it was unless it is changed.
+type source struct {
+	// Text from the end of the previous node through the value or opening
+	// brace, except the value itself. Document sources hold the BOM.
+	head string
+	// Value as written, e.g. `"30"` or `30`.
+	value string
+	// Text after the value or opening brace until the next node.
+	tail string
+	// Text through the closing brace of a block until the next node.
+	close string
+	// Fields of node as read.
+	name, val, cond, comment string
+	typ                      nodeType
+	top                      bool
+}
+
+// newSource returns source of node with given head, holding its
+// current fields.
+func newSource(n *Node, head string) *source {
+	return &source{
+		head:    head,
+		name:    n.Name,
+		val:     n.value,
+		cond:    n.Condition,
+		comment: n.Comment,
+		typ:     n.typ,
+	}
+}
+
+// unchanged returns true if n can be written as its source, with at most
+// its value replaced.
+func (n *Node) unchanged() bool {
+	return n.src != nil && !n.src.top && n.src.typ == n.typ && n.src.name == n.Name &&
+		n.src.cond == n.Condition && n.src.comment == n.Comment
+}
+
+// NewDocument returns an empty document, which is a block without name
+// holding the nodes at the top level.
+func NewDocument() *Node {
+	return &Node{typ: _NODE_BLOCK}
+}
+
+// Options represents how a document is loaded.
+type Options struct {
+	// Resolve indicates whether to load files named by #base and #include
+	// statements, relative to the directory of the document. Nodes of
+	// #include files are added at the top level, and nodes of #base files
+	// are merged in where the document does not set them.
+	Resolve bool
+	// Defines are the symbols conditionals are evaluated with, e.g.
+	// {"$WIN32": true}. Nodes whose conditional is false are dropped.
+	// Conditionals are kept as is when it is nil.
+	Defines map[string]bool
+}
+
+// Parse parses a document from r.
+func Parse(r io.Reader) (*Node, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
+}
+
+// Load loads and parses a document from a file.
+func Load(filename string) (*Node, error) {
+	return LoadWithOptions(Options{}, filename)
+}
+
+// LoadWithOptions loads and parses a document from a file with given options.
+func LoadWithOptions(opts Options, filename string) (*Node, error) {
+	doc, err := loadFile(filename, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Defines != nil {
+		doc.filter(opts.Defines)
+	}
+	return doc, nil
+}
+
+// loadFile loads a file, resolving its directives with opts. Chain is
+// the files that led to this one, to detect cycles.
+func loadFile(filename string, opts Options, chain []string) (*Node, error) {
+	for _, name := range chain {
+		if name == filename {
+			return nil, fmt.Errorf("directive cycle detected: %s -> %s", strings.Join(chain, " -> "), filename)
+		}
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	if !opts.Resolve {
+		return doc, nil
+	}
+
+	chain = append(chain, filename)
+	nodes := doc.children[:0]
+	var bases []*Node
+	for _, n := range doc.children {
+		if n.typ != _NODE_DIRECTIVE {
+			nodes = append(nodes, n)
+			continue
+		}
+
+		other, err := loadFile(filepath.Join(filepath.Dir(filename), n.value), opts, chain)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(n.Name, "#include") {
+			nodes = append(nodes, other.children...)
+		} else {
+			bases = append(bases, other)
+		}
+	}
+	doc.children = nodes
+
+	// Like the engine, keys of a base are merged into the first key of the
+	// document, whatever names the two have.
+	for _, base := range bases {
+		if root, baseRoot := doc.firstBlock(), base.firstBlock(); root != nil && baseRoot != nil {
+			root.mergeBase(baseRoot)
+		} else {
+			doc.mergeBase(base)
+		}
+	}
+	return doc, nil
+}
+
+// firstBlock returns the first child block of n, or nil if there is none.
+func (n *Node) firstBlock() *Node {
+	for _, cn := range n.children {
+		if cn.typ == _NODE_BLOCK {
+			return cn
+		}
+	}
+	return nil
+}
+
+// mergeBase adds nodes of base that n does not have, merging blocks
+// that both have.
+func (n *Node) mergeBase(base *Node) {
+	for _, bn := range base.Children() {
+		cn := n.Child(bn.Name)
+		switch {
+		case cn == nil:
+			n.children = append(n.children, bn)
+		case cn.IsBlock() && bn.IsBlock():
+			cn.mergeBase(bn)
+		}
+	}
+}
+
+// filter drops nodes whose conditional is false with given defines.
+func (n *Node) filter(defines map[string]bool) {
+	nodes := n.children[:0]
+	for _, cn := range n.children {
+		if len(cn.Condition) > 0 && !EvalCondition(cn.Condition, defines) {
+			continue
+		}
+		if cn.typ == _NODE_BLOCK {
+			cn.filter(defines)
+		}
+		nodes = append(nodes, cn)
+	}
+	n.children = nodes
+}
+
+// EvalCondition evaluates a conditional such as "$WIN32", "!$X360" or
+// "$WIN32||$OSX" with given defines. "&&" binds tighter than "||".
+func EvalCondition(cond string, defines map[string]bool) bool {
+	cond = strings.Trim(strings.TrimSpace(cond), "[]")
+	for _, or := range strings.Split(cond, "||") {
+		all := true
+		for _, and := range strings.Split(or, "&&") {
+			sym := strings.TrimSpace(and)
+			want := true
+			for strings.HasPrefix(sym, "!") {
+				want = !want
+				sym = strings.TrimSpace(sym[1:])
+			}
+			if defines[sym] != want {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlock returns true if node holds child nodes rather than a value.
+func (n *Node) IsBlock() bool {
+	return n.typ == _NODE_BLOCK
+}
+
+// Line returns line number node was read from,
+// or zero if it was not read from a document.
+func (n *Node) Line() int {
+	return n.line
+}
+
+// Value returns value of node, or an empty string for a block.
+func (n *Node) Value() string {
+	return n.value
+}
+
+// SetValue changes value of node.
+func (n *Node) SetValue(v string) {
+	n.value = v
+}
+
+// String returns string representation of value.
+func (n *Node) String() string {
+	return n.value
+}
+
+// Bool returns bool type value, where "1" is true and "0" false.
+func (n *Node) Bool() (bool, error) {
+	return strconv.ParseBool(n.value)
+}
+
+// Int returns int type value.
+func (n *Node) Int() (int, error) {
+	return strconv.Atoi(n.value)
+}
+
+// Int64 returns int64 type value.
+func (n *Node) Int64() (int64, error) {
+	return strconv.ParseInt(n.value, 10, 64)
+}
+
+// Uint64 returns uint64 type value.
+func (n *Node) Uint64() (uint64, error) {
+	return strconv.ParseUint(n.value, 10, 64)
+}
+
+// Float64 returns float64 type value.
+func (n *Node) Float64() (float64, error) {
+	return strconv.ParseFloat(n.value, 64)
+}
+
+// Children returns child nodes of a block, without comments and directives.
+func (n *Node) Children() []*Node {
+	var nodes []*Node
+	for _, cn := range n.children {
+		if cn.typ == _NODE_VALUE || cn.typ == _NODE_BLOCK {
+			nodes = append(nodes, cn)
+		}
+	}
+	return nodes
+}
+
+// Child returns the first child node with given name, or nil if there is none.
+func (n *Node) Child(name string) *Node {
+	for _, cn := range n.children {
+		if (cn.typ == _NODE_VALUE || cn.typ == _NODE_BLOCK) && strings.EqualFold(cn.Name, name) {
+			return cn
+		}
+	}
+	return nil
+}
+
+// GetChild returns node by given path of names, e.g.
+// GetChild("gameTypes", "classic", "gameModes").
+func (n *Node) GetChild(path ...string) (*Node, error) {
+	cn := n
+	for i, name := range path {
+		if cn = cn.Child(name); cn == nil {
+			return nil, ErrNodeNotFound{path[:i+1]}
+		}
+	}
+	return cn, nil
+}
+
+// Block returns child block with given name, creating it at the end
+// of node if it does not exist.
+func (n *Node) Block(name string) *Node {
+	if cn := n.Child(name); cn != nil && cn.IsBlock() {
+		return cn
+	}
+	cn := &Node{typ: _NODE_BLOCK, Name: name}
+	n.children = append(n.children, cn)
+	return cn
+}
+
+// SetChild sets value of child node with given name, creating it at
+// the end of node if it does not exist.
+func (n *Node) SetChild(name, value string) *Node {
+	if cn := n.Child(name); cn != nil && !cn.IsBlock() {
+		cn.value = value
+		return cn
+	}
+	cn := &Node{typ: _NODE_VALUE, Name: name, value: value}
+	n.children = append(n.children, cn)
+	return cn
+}
+
+// DeleteChild deletes every child node with given name.
+func (n *Node) DeleteChild(name string) {
+	nodes := n.children[:0]
+	for _, cn := range n.children {
+		if (cn.typ == _NODE_VALUE || cn.typ == _NODE_BLOCK) && strings.EqualFold(cn.Name, name) {
+			continue
+		}
+		nodes = append(nodes, cn)
+	}
+	n.children = nodes
+}
+
+// quote wraps s in "". The format has no escape sequences,
+// so a value holding a quote cannot be written.
+func quote(s string) (string, error) {
+	if strings.IndexByte(s, '"') >= 0 {
+		return "", fmt.Errorf("%q cannot be written: it holds a quote", s)
+	}
+	return `"` + s + `"`, nil
+}
+
+// writeNodes writes nodes of a block with given indent. Nodes read from a
+// document are written as they were read unless changed, and changed values
+// replace only the value as written.
+func writeNodes(buf *bytes.Buffer, nodes []*Node, indent string) error {
+	for _, n := range nodes {
+		if n.unchanged() {
+			buf.WriteString(n.src.head)
+			if n.value == n.src.val {
+				buf.WriteString(n.src.value)
+			} else if val, err := quote(n.value); err != nil {
+				return err
+			} else {
+				buf.WriteString(val)
+			}
+			buf.WriteString(n.src.tail)
+
+			if n.typ == _NODE_BLOCK {
+				if err := writeNodes(buf, n.children, indent+"\t"); err != nil {
+					return err
+				}
+				buf.WriteString(n.src.close)
+			}
+			continue
+		}
+
+		// Nodes read on one line may be followed by a new or changed one.
+		if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteString(LineBreak)
+		}
+
+		if n.typ == _NODE_BLANK {
+			buf.WriteString(LineBreak)
+			continue
+		}
+
+		buf.WriteString(indent)
+		if n.typ == _NODE_COMMENT {
+			buf.WriteString(n.Comment + LineBreak)
+			continue
+		}
+
+		val, err := quote(n.value)
+		if err != nil {
+			return err
+		}
+		if n.typ == _NODE_DIRECTIVE {
+			buf.WriteString(n.Name + " " + val + LineBreak)
+			continue
+		}
+
+		name, err := quote(n.Name)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(name)
+		if n.typ != _NODE_BLOCK {
+			buf.WriteString("\t\t" + val)
+		}
+		if len(n.Condition) > 0 {
+			buf.WriteString(" [" + n.Condition + "]")
+		}
+		if len(n.Comment) > 0 {
+			buf.WriteString(" " + n.Comment)
+		}
+		buf.WriteString(LineBreak)
+
+		if n.typ == _NODE_BLOCK {
+			buf.WriteString(indent + "{" + LineBreak)
+			if err = writeNodes(buf, n.children, indent+"\t"); err != nil {
+				return err
+			}
+			if buf.Bytes()[buf.Len()-1] != '\n' {
+				buf.WriteString(LineBreak)
+			}
+			buf.WriteString(indent + "}" + LineBreak)
+		}
+	}
+	return nil
+}
+
+// WriteTo writes child nodes of n as a document into io.Writer. Nodes read
+// from a document keep their layout, and only nodes that are added or whose
+// name, conditional or comment is changed are written in canonical form,
+// indented by tabs.
+func (n *Node) WriteTo(w io.Writer) (int64, error) {
+	// Use buffer to make sure target is safe until finish encoding.
+	var buf bytes.Buffer
+	if err := writeNodes(&buf, n.children, ""); err != nil {
+		return 0, err
+	}
+	if n.src != nil && n.src.top {
+		buf = *bytes.NewBufferString(n.src.head + buf.String() + n.src.tail)
+	}
+	return buf.WriteTo(w)
+}
+
+// SaveTo writes document to file system.
+func (n *Node) SaveTo(filename string) error {
+	// Note: Because we are truncating with os.Create,
+	// 	so it's safer to save to a temporary file location and rename afte done.
+	tmpPath := filename + "." + strconv.Itoa(time.Now().Nanosecond()) + ".tmp"
+	defer os.Remove(tmpPath)
+
+	fw, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err = n.WriteTo(fw); err != nil {
+		fw.Close()
+		return err
+	}
+	fw.Close()
+
+	// Remove old file and rename the new one.
+	os.Remove(filename)
+	return os.Rename(tmpPath, filename)
+}
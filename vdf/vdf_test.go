@@ -0,0 +1,174 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package vdf
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Parse(t *testing.T) {
+	Convey("Parse a document", t, func() {
+		doc, err := Load("testdata/gamemodes_server.txt")
+		So(err, ShouldBeNil)
+
+		comp, err := doc.GetChild("GameModes_Server.txt", "gameTypes", "classic", "gameModes", "competitive")
+		So(err, ShouldBeNil)
+		So(comp.IsBlock(), ShouldBeTrue)
+		So(comp.Child("MAXPLAYERS").Value(), ShouldEqual, "10")
+		So(comp.Line(), ShouldEqual, 12)
+
+		convars := comp.Child("convars")
+		So(convars.Children(), ShouldHaveLength, 4)
+		So(convars.Child("mp_maxrounds").Comment, ShouldEqual, "// MR15")
+		So(convars.Children()[2].Condition, ShouldEqual, "$WIN32")
+		So(convars.Children()[3].Condition, ShouldEqual, "!$WIN32")
+
+		_, err = doc.GetChild("GameModes_Server.txt", "gameTypes", "casual")
+		So(IsErrNodeNotFound(err), ShouldBeTrue)
+		So(err.Error(), ShouldEqual, "node 'GameModes_Server.txt/gameTypes/casual' does not exist")
+
+		Convey("Write document back", func() {
+			data, err := os.ReadFile("testdata/gamemodes_server.txt")
+			So(err, ShouldBeNil)
+
+			var buf bytes.Buffer
+			_, err = doc.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, string(data))
+		})
+	})
+
+	Convey("Keep layout of a document", t, func() {
+		data, err := os.ReadFile("testdata/gamemodes_server_raw.txt")
+		So(err, ShouldBeNil)
+		doc, err := Load("testdata/gamemodes_server_raw.txt")
+		So(err, ShouldBeNil)
+
+		var buf bytes.Buffer
+		_, err = doc.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, string(data))
+
+		Convey("Write changed nodes only", func() {
+			comp, err := doc.GetChild("GameModes_Server.txt", "gameTypes", "classic", "gameModes", "competitive")
+			So(err, ShouldBeNil)
+			comp.Child("maxplayers").SetValue("12")
+			convars := comp.Child("convars")
+			convars.Child("mp_freezetime").Comment = "// seconds"
+			convars.SetChild("mp_halftime", "1")
+
+			buf.Reset()
+			_, err = doc.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			want := strings.Replace(string(data), "maxplayers 10   // 5v5", `maxplayers "12"   // 5v5`, 1)
+			want = strings.Replace(want, "\t      \"mp_freezetime\"\t15\n",
+				"\t\t\t\t\t\t\"mp_freezetime\"\t\t\"15\" // seconds"+LineBreak, 1)
+			want = strings.Replace(want, "\t      sv_allow_votes\t\"1\"\t[!$WIN32]\n",
+				"\t      sv_allow_votes\t\"1\"\t[!$WIN32]\n\t\t\t\t\t\t\"mp_halftime\"\t\t\"1\""+LineBreak, 1)
+			So(buf.String(), ShouldEqual, want)
+		})
+	})
+
+	Convey("Keep BOM and line breaks of a document", t, func() {
+		data := "\xef\xbb\xbf\"a\" { \"b\" \"1\" }\r\n\r\n\"c\"\t2 // two\r\n  "
+		doc, err := Parse(strings.NewReader(data))
+		So(err, ShouldBeNil)
+
+		var buf bytes.Buffer
+		_, err = doc.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, data)
+	})
+
+	Convey("Parse unquoted tokens and conditionals", t, func() {
+		doc, err := Parse(strings.NewReader(`Bot { Name "Rock" [$X360&&!$PS3] Skill 50 } // end
+"multi"	"line one
+line two"`))
+		So(err, ShouldBeNil)
+		So(doc.Child("bot").Child("skill").Value(), ShouldEqual, "50")
+		So(doc.Child("multi").Value(), ShouldEqual, "line one\nline two")
+
+		So(EvalCondition(doc.Child("bot").Child("name").Condition, map[string]bool{"$X360": true}), ShouldBeTrue)
+		So(EvalCondition("$X360&&!$PS3", map[string]bool{"$X360": true, "$PS3": true}), ShouldBeFalse)
+		So(EvalCondition("[$WIN32||$OSX]", map[string]bool{"$OSX": true}), ShouldBeTrue)
+	})
+
+	Convey("Report syntax errors", t, func() {
+		_, err := Parse(strings.NewReader("\"a\"\n{\n\t\"b\" \"1\"\n"))
+		So(IsErrSyntax(err), ShouldBeTrue)
+		So(err.Error(), ShouldEqual, "line 1: block 'a' without closing '}'")
+
+		_, err = Parse(strings.NewReader("\"a\" \"1\"\n}"))
+		So(err, ShouldResemble, ErrSyntax{2, "unexpected '}'"})
+
+		_, err = Parse(strings.NewReader(`"a" "1`))
+		So(IsErrSyntax(err), ShouldBeTrue)
+	})
+}
+
+func Test_LoadWithOptions(t *testing.T) {
+	Convey("Resolve directives and conditionals", t, func() {
+		doc, err := LoadWithOptions(Options{Resolve: true, Defines: map[string]bool{"$WIN32": true}}, "testdata/gamemodes_server.txt")
+		So(err, ShouldBeNil)
+
+		modes, err := doc.GetChild("GameModes_Server.txt", "gameTypes", "classic", "gameModes")
+		So(err, ShouldBeNil)
+		So(modes.Children(), ShouldHaveLength, 2)
+		So(modes.Child("casual").Child("maxplayers").Value(), ShouldEqual, "20")
+		So(modes.Child("competitive").Child("maxplayers").Value(), ShouldEqual, "10")
+		So(modes.Child("competitive").Child("nameID").Value(), ShouldEqual, "#SFUI_GameModeCompetitive")
+
+		votes := modes.Child("competitive").Child("convars").Child("sv_allow_votes")
+		So(votes.Value(), ShouldEqual, "0")
+
+		Convey("Drop directives once resolved", func() {
+			var buf bytes.Buffer
+			_, err := doc.WriteTo(&buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldNotContainSubstring, "#base")
+		})
+	})
+}
+
+func Test_Node_Edit(t *testing.T) {
+	Convey("Edit nodes", t, func() {
+		doc := NewDocument()
+		root := doc.Block("subscribed_collection_ids")
+		root.SetChild("1", "123456789")
+		root.SetChild("2", "987654321")
+		root.SetChild("1", "111")
+		root.Child("2").Condition = "$WIN32"
+		doc.Block("other").SetChild("x", "y")
+		doc.DeleteChild("OTHER")
+
+		var buf bytes.Buffer
+		_, err := doc.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, `"subscribed_collection_ids"`+LineBreak+
+			`{`+LineBreak+
+			"\t\"1\"\t\t\"111\""+LineBreak+
+			"\t\"2\"\t\t\"987654321\" [$WIN32]"+LineBreak+
+			`}`+LineBreak)
+
+		root.SetChild("3", `say "hi"`)
+		_, err = doc.WriteTo(&buf)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,307 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// Default time to wait for more writes before reloading.
+	_WATCH_DEBOUNCE = 100 * time.Millisecond
+	// Default interval of checking files when polling.
+	_WATCH_POLL_INTERVAL = time.Second
+)
+
+// WatchOptions contains options of watching files of a config.
+type WatchOptions struct {
+	// Debounce is how long to wait after a change for more changes before
+	// reloading, so that a burst of writes causes a single reload.
+	// 100ms when zero.
+	Debounce time.Duration
+	// Poll indicates whether to check modification time of files instead of
+	// using notifications of the OS, which are only supported on Linux.
+	Poll bool
+	// PollInterval is how often files are checked when polling, 1s when zero.
+	PollInterval time.Duration
+}
+
+// WatchEvent represents result of reloading a config after its files are changed.
+type WatchEvent struct {
+	// Changes of effective values made by the reload.
+	Changes []*Change
+	// Err is the error of a failed reload, in which case
+	// the config keeps its last good content.
+	Err error
+}
+
+// notifier reports changes of a set of files.
+type notifier interface {
+	// setPaths replaces the set of files to watch.
+	setPaths(paths []string) error
+	// changes delivers paths of files that are changed, created or removed.
+	changes() <-chan string
+	errors() <-chan error
+	close() error
+}
+
+// Watcher reloads a config when its files are changed.
+type Watcher struct {
+	f        *File
+	opts     WatchOptions
+	notifier notifier
+	events   chan *WatchEvent
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// watchPaths returns paths of data source files and files loaded by
+// "exec" statements, which make the content of the config.
+func (f *File) watchPaths() []string {
+	var paths []string
+	for _, s := range f.dataSources {
+		if name := sourceName(s); len(name) > 0 {
+			paths = append(paths, absPath(name))
+		}
+	}
+	for _, inc := range f.includes {
+		paths = append(paths, inc.File.watchPaths()...)
+	}
+	return paths
+}
+
+// replace replaces content of f with the one of nf.
+func (f *File) replace(nf *File) {
+	if f.BlockMode {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+	}
+
+	for _, sec := range nf.sections {
+		sec.f = f
+	}
+	f.sections = nf.sections
+	f.sectionList = nf.sectionList
+	f.includes = nf.includes
+	f.parseErrors = nf.parseErrors
+}
+
+// Watch starts watching data source files of the config, and files loaded
+// by "exec" statements, reloading it when they are changed. Content is
+// replaced only after a reload succeeds, and the result of every reload
+// is delivered by Events until the watcher is closed.
+func (f *File) Watch(opts WatchOptions) (*Watcher, error) {
+	if opts.Debounce <= 0 {
+		opts.Debounce = _WATCH_DEBOUNCE
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = _WATCH_POLL_INTERVAL
+	}
+
+	var n notifier
+	var err error
+	if opts.Poll {
+		n = newPollNotifier(opts.PollInterval)
+	} else if n, err = newNotifier(opts); err != nil {
+		return nil, err
+	}
+	if err = n.setPaths(f.watchPaths()); err != nil {
+		n.close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		f:        f,
+		opts:     opts,
+		notifier: n,
+		events:   make(chan *WatchEvent, 1),
+		done:     make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// Events returns the channel that delivers results of reloads.
+// It is closed when the watcher is closed.
+func (w *Watcher) Events() <-chan *WatchEvent {
+	return w.events
+}
+
+// Close stops watching files. It is safe to call more than once, and from
+// several goroutines at the same time.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.closeErr = w.notifier.close()
+		w.wg.Wait()
+		close(w.events)
+	})
+	return w.closeErr
+}
+
+func (w *Watcher) send(ev *WatchEvent) {
+	select {
+	case w.events <- ev:
+	case <-w.done:
+	}
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	timer := time.NewTimer(w.opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case _, ok := <-w.notifier.changes():
+			if !ok {
+				return
+			}
+			timer.Reset(w.opts.Debounce)
+		case err := <-w.notifier.errors():
+			w.send(&WatchEvent{Err: err})
+		case <-timer.C:
+			w.reload()
+		}
+	}
+}
+
+// reload parses files into a new config and replaces content of
+// the watched one with it if there is no error.
+func (w *Watcher) reload() {
+	f := w.f
+	nf := newFile(f.dataSources, f.options)
+	nf.execStack = f.execStack
+	nf.execDepth = f.execDepth
+	nf.NameMapper = f.NameMapper
+	nf.ValueMapper = f.ValueMapper
+	if err := nf.Reload(); err != nil {
+		w.send(&WatchEvent{Err: err})
+		return
+	}
+
+	changes := Diff(f, nf)
+	f.replace(nf)
+
+	// Includes may have changed.
+	if err := w.notifier.setPaths(f.watchPaths()); err != nil {
+		w.send(&WatchEvent{Changes: changes, Err: err})
+		return
+	}
+	if len(changes) > 0 {
+		w.send(&WatchEvent{Changes: changes})
+	}
+}
+
+// fileState is what polling compares to find changed files.
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileState {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{true, fi.ModTime(), fi.Size()}
+}
+
+// pollNotifier finds changed files by checking them periodically.
+type pollNotifier struct {
+	lock    sync.Mutex
+	states  map[string]fileState
+	changed chan string
+	errs    chan error
+	done    chan struct{}
+}
+
+func newPollNotifier(interval time.Duration) *pollNotifier {
+	n := &pollNotifier{
+		states:  make(map[string]fileState),
+		changed: make(chan string),
+		errs:    make(chan error),
+		done:    make(chan struct{}),
+	}
+	go n.run(interval)
+	return n
+}
+
+func (n *pollNotifier) setPaths(paths []string) error {
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		states[path] = statFile(path)
+	}
+
+	n.lock.Lock()
+	n.states = states
+	n.lock.Unlock()
+	return nil
+}
+
+func (n *pollNotifier) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.done:
+			return
+		case <-ticker.C:
+		}
+
+		var changed []string
+		n.lock.Lock()
+		for path, old := range n.states {
+			if cur := statFile(path); cur != old {
+				n.states[path] = cur
+				changed = append(changed, path)
+			}
+		}
+		n.lock.Unlock()
+
+		for _, path := range changed {
+			select {
+			case n.changed <- path:
+			case <-n.done:
+				return
+			}
+		}
+	}
+}
+
+func (n *pollNotifier) changes() <-chan string {
+	return n.changed
+}
+
+func (n *pollNotifier) errors() <-chan error {
+	return n.errs
+}
+
+func (n *pollNotifier) close() error {
+	close(n.done)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package csgo_cfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Directories are watched rather than files, so that files replaced by
+// editors that write a new file and rename it are still seen.
+const _INOTIFY_MASK = syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM
+
+// inotifyNotifier finds changed files with inotify.
+type inotifyNotifier struct {
+	fd   int
+	file *os.File
+
+	lock  sync.Mutex
+	dirs  map[string]int // Watch descriptors of directories
+	names map[int]string // Directories of watch descriptors
+	paths map[string]bool
+
+	changed chan string
+	errs    chan error
+	done    chan struct{}
+}
+
+func newNotifier(opts WatchOptions) (notifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("error watching files: %v", err)
+	}
+
+	n := &inotifyNotifier{
+		fd: fd,
+		// Reads of a non-blocking file go through the runtime poller,
+		// so closing it stops the pending read.
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		dirs:    make(map[string]int),
+		names:   make(map[int]string),
+		paths:   make(map[string]bool),
+		changed: make(chan string),
+		errs:    make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+	go n.run()
+	return n, nil
+}
+
+func (n *inotifyNotifier) setPaths(paths []string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.paths = make(map[string]bool, len(paths))
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		n.paths[path] = true
+		dirs[filepath.Dir(path)] = true
+	}
+
+	for dir, wd := range n.dirs {
+		if !dirs[dir] {
+			syscall.InotifyRmWatch(n.fd, uint32(wd))
+			delete(n.dirs, dir)
+			delete(n.names, wd)
+		}
+	}
+	for dir := range dirs {
+		if _, ok := n.dirs[dir]; ok {
+			continue
+		}
+		wd, err := syscall.InotifyAddWatch(n.fd, dir, _INOTIFY_MASK)
+		if err != nil {
+			return fmt.Errorf("error watching directory '%s': %v", dir, err)
+		}
+		n.dirs[dir] = wd
+		n.names[wd] = dir
+	}
+	return nil
+}
+
+func (n *inotifyNotifier) run() {
+	defer close(n.changed)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		size, err := n.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				n.errs <- fmt.Errorf("error reading inotify events: %v", err)
+			}
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= size; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(ev.Len)]
+			offset += syscall.SizeofInotifyEvent + int(ev.Len)
+
+			name := string(nameBytes)
+			for i := 0; i < len(name); i++ {
+				if name[i] == 0 {
+					name = name[:i]
+					break
+				}
+			}
+
+			n.lock.Lock()
+			dir, ok := n.names[int(ev.Wd)]
+			path := filepath.Join(dir, name)
+			watched := ok && n.paths[path]
+			n.lock.Unlock()
+
+			if watched {
+				select {
+				case n.changed <- path:
+				case <-n.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (n *inotifyNotifier) changes() <-chan string {
+	return n.changed
+}
+
+func (n *inotifyNotifier) errors() <-chan error {
+	return n.errs
+}
+
+func (n *inotifyNotifier) close() error {
+	close(n.done)
+	return n.file.Close()
+}
@@ -0,0 +1,118 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package csgo_cfg
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// nextEvent returns the next event of watcher, or nil if there is none in time.
+func nextEvent(w *Watcher) *WatchEvent {
+	select {
+	case ev := <-w.Events():
+		return ev
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func testWatch(t *testing.T, opts WatchOptions) {
+	dir := t.TempDir()
+	server := filepath.Join(dir, "server.cfg")
+	gamemode := filepath.Join(dir, "gamemode.cfg")
+	So(os.WriteFile(server, []byte("hostname \"Test\"\nmp_maxrounds 30\nexec gamemode\n"), 0644), ShouldBeNil)
+	So(os.WriteFile(gamemode, []byte("mp_freezetime 15\n"), 0644), ShouldBeNil)
+
+	cfg, err := LoadSources(LoadOptions{ResolveExec: true}, server)
+	So(err, ShouldBeNil)
+
+	w, err := cfg.Watch(opts)
+	So(err, ShouldBeNil)
+	defer w.Close()
+
+	// Modification time may not change between writes that are too close.
+	time.Sleep(20 * time.Millisecond)
+
+	So(os.WriteFile(server, []byte("mp_maxrounds 24\nexec gamemode\nsv_cheats 0\n"), 0644), ShouldBeNil)
+	ev := nextEvent(w)
+	So(ev, ShouldNotBeNil)
+	So(ev.Err, ShouldBeNil)
+	So(ev.Changes, ShouldHaveLength, 3)
+	So(ev.Changes[0].Name, ShouldEqual, "hostname")
+	So(ev.Changes[0].Type, ShouldEqual, ChangeRemoved)
+	So(ev.Changes[1].Name, ShouldEqual, "mp_maxrounds")
+	So(ev.Changes[1].OldValue, ShouldEqual, "30")
+	So(ev.Changes[1].NewValue, ShouldEqual, "24")
+	So(ev.Changes[2].Type, ShouldEqual, ChangeAdded)
+	So(cfg.Section("").Key("mp_maxrounds").Value(), ShouldEqual, "24")
+
+	// Files loaded by exec are watched as well.
+	So(os.WriteFile(gamemode, []byte("mp_freezetime 10\n"), 0644), ShouldBeNil)
+	ev = nextEvent(w)
+	So(ev, ShouldNotBeNil)
+	So(ev.Changes, ShouldHaveLength, 1)
+	So(ev.Changes[0].NewValue, ShouldEqual, "10")
+
+	// Bad content keeps the last good one.
+	time.Sleep(20 * time.Millisecond)
+	So(os.WriteFile(server, []byte("hostname \"unterminated\n"), 0644), ShouldBeNil)
+	ev = nextEvent(w)
+	So(ev, ShouldNotBeNil)
+	So(IsParseError(ev.Err), ShouldBeTrue)
+	So(cfg.Section("").Key("mp_maxrounds").Value(), ShouldEqual, "24")
+
+	So(w.Close(), ShouldBeNil)
+	_, ok := <-w.Events()
+	So(ok, ShouldBeFalse)
+}
+
+func Test_Watch(t *testing.T) {
+	Convey("Watch config files", t, func() {
+		testWatch(t, WatchOptions{Debounce: 20 * time.Millisecond})
+	})
+
+	Convey("Watch config files by polling", t, func() {
+		testWatch(t, WatchOptions{Debounce: 20 * time.Millisecond, Poll: true, PollInterval: 10 * time.Millisecond})
+	})
+
+	Convey("Close watcher from several goroutines", t, func() {
+		server := filepath.Join(t.TempDir(), "server.cfg")
+		So(os.WriteFile(server, []byte("mp_maxrounds 30\n"), 0644), ShouldBeNil)
+		cfg, err := Load(server)
+		So(err, ShouldBeNil)
+
+		w, err := cfg.Watch(WatchOptions{})
+		So(err, ShouldBeNil)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 4)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = w.Close()
+			}(i)
+		}
+		wg.Wait()
+		So(errs, ShouldResemble, make([]error, 4))
+		So(w.Close(), ShouldBeNil)
+	})
+}